@@ -0,0 +1,246 @@
+package converter
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	fsstore "github.com/portainer/k2d/internal/adapter/store/filesystem"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/apis/core"
+	k8scorev1 "k8s.io/kubernetes/pkg/apis/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// ConvertContainerToPodYAML reconstructs a Kubernetes Pod manifest from a Docker container's
+// inspect data and renders it as YAML. This is the inverse of
+// ConvertPodSpecToContainerConfiguration, and mirrors Podman's GenerateForKube: env vars,
+// port bindings, binds, restart policy, resource limits and security context are all read
+// back off the container and translated to their Kubernetes spec equivalents. It is used for
+// `kubectl get pod -o yaml` fidelity and for exporting a running k2d workload back into a
+// manifest that can be re-applied elsewhere.
+func (converter *DockerAPIConverter) ConvertContainerToPodYAML(containerJSON types.ContainerJSON) ([]byte, error) {
+	pod := convertContainerJSONToPod(containerJSON)
+
+	// core.Pod is the internal type and carries no JSON tags, so it is converted to the
+	// versioned v1.Pod before marshaling to get the standard manifest field names.
+	var versionedPod corev1.Pod
+	if err := k8scorev1.Convert_core_Pod_To_v1_Pod(&pod, &versionedPod, nil); err != nil {
+		return nil, fmt.Errorf("unable to convert pod to v1: %w", err)
+	}
+	versionedPod.TypeMeta = pod.TypeMeta
+
+	data, err := yaml.Marshal(versionedPod)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal pod to yaml: %w", err)
+	}
+
+	return data, nil
+}
+
+// convertContainerJSONToPod builds a core.Pod out of a single Docker container's inspect data.
+func convertContainerJSONToPod(containerJSON types.ContainerJSON) core.Pod {
+	containerName := strings.TrimPrefix(containerJSON.Name, "/")
+	volumes, volumeMounts := generateVolumes(containerJSON.HostConfig.Binds)
+
+	containerSpec := core.Container{
+		Name:            containerName,
+		Image:           containerJSON.Config.Image,
+		Env:             generateEnvVars(containerJSON.Config.Env),
+		Ports:           generatePorts(containerJSON.HostConfig.PortBindings),
+		VolumeMounts:    volumeMounts,
+		Resources:       generateResourceRequirements(containerJSON.HostConfig.Resources),
+		SecurityContext: generateContainerSecurityContext(containerJSON.HostConfig, containerJSON.Config),
+	}
+
+	var namespace string
+	if containerJSON.Config != nil {
+		namespace = containerJSON.Config.Labels[PodNamespaceLabelKey]
+	}
+
+	return core.Pod{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Pod",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      containerName,
+			Namespace: namespaceOrDefault(namespace),
+		},
+		Spec: core.PodSpec{
+			Containers:    []core.Container{containerSpec},
+			Volumes:       volumes,
+			RestartPolicy: generateRestartPolicy(containerJSON.HostConfig.RestartPolicy),
+		},
+	}
+}
+
+// generateEnvVars converts a Docker container's "KEY=VALUE" environment slice back into
+// Kubernetes EnvVar entries.
+func generateEnvVars(env []string) []core.EnvVar {
+	var envVars []core.EnvVar
+
+	for _, entry := range env {
+		key, value, found := strings.Cut(entry, "=")
+		if !found {
+			continue
+		}
+
+		envVars = append(envVars, core.EnvVar{Name: key, Value: value})
+	}
+
+	return envVars
+}
+
+// generatePorts converts a Docker port binding map back into Kubernetes ContainerPort
+// entries. This is the inverse of setHostPorts.
+func generatePorts(portBindings nat.PortMap) []core.ContainerPort {
+	var ports []core.ContainerPort
+
+	for containerPort, bindings := range portBindings {
+		for _, binding := range bindings {
+			hostPort, err := strconv.Atoi(binding.HostPort)
+			if err != nil {
+				continue
+			}
+
+			ports = append(ports, core.ContainerPort{
+				ContainerPort: int32(containerPort.Int()),
+				HostPort:      int32(hostPort),
+				Protocol:      core.Protocol(strings.ToUpper(containerPort.Proto())),
+			})
+		}
+	}
+
+	return ports
+}
+
+// generateRestartPolicy converts a Docker restart policy back into a Kubernetes
+// RestartPolicy. This is the inverse of setRestartPolicy.
+func generateRestartPolicy(policy container.RestartPolicy) core.RestartPolicy {
+	switch policy.Name {
+	case "on-failure":
+		return core.RestartPolicyOnFailure
+	case "no":
+		return core.RestartPolicyNever
+	default:
+		return core.RestartPolicyAlways
+	}
+}
+
+// generateResourceRequirements converts Docker resource constraints back into Kubernetes
+// ResourceRequirements, rebuilding the relevant quantities via resource.Quantity so the
+// output uses standard Kubernetes spellings (e.g. "256Mi") rather than raw byte counts.
+func generateResourceRequirements(resources container.Resources) core.ResourceRequirements {
+	limits := core.ResourceList{}
+
+	if resources.Memory > 0 {
+		limits[core.ResourceMemory] = *resource.NewQuantity(resources.Memory, resource.BinarySI)
+	}
+
+	if resources.NanoCPUs > 0 {
+		limits[core.ResourceCPU] = *resource.NewMilliQuantity(resources.NanoCPUs/1000000, resource.DecimalSI)
+	}
+
+	requirements := core.ResourceRequirements{}
+	if len(limits) > 0 {
+		requirements.Limits = limits
+	}
+
+	return requirements
+}
+
+// volumeGroupKey identifies the single Kubernetes Volume that a bind belongs to. A ConfigMap
+// or Secret with several keys produces one bind per key but all of them share the same
+// resource name and mount directory, and must be reconstructed as a single Volume/VolumeMount
+// pair - Kubernetes rejects a Pod spec with two VolumeMounts at the same MountPath.
+type volumeGroupKey struct {
+	kind      string // "configmap", "secret" or "hostpath"
+	name      string // ConfigMap/Secret name, or the host path for a "hostpath" bind
+	mountPath string
+}
+
+// generateVolumes rehydrates a container's bind mounts into Kubernetes Volumes and
+// VolumeMounts. Binds whose host path lives under the FileSystemStore's ConfigMap or Secret
+// directories are reconstructed as ConfigMap/Secret volumes; every other bind is reconstructed
+// as a HostPath volume. Binds that belong to the same ConfigMap/Secret and mount directory -
+// one per key - are grouped into a single Volume/VolumeMount, mirroring how setVolumeMounts
+// expands a single ConfigMap/Secret volume into one bind per key.
+func generateVolumes(binds []string) ([]core.Volume, []core.VolumeMount) {
+	var order []volumeGroupKey
+	sources := map[volumeGroupKey]core.VolumeSource{}
+
+	for _, bind := range binds {
+		hostPath, mountPath, found := strings.Cut(bind, ":")
+		if !found {
+			continue
+		}
+
+		var key volumeGroupKey
+		var source core.VolumeSource
+
+		if name, ok := resolveStoreBind(hostPath, fsstore.ConfigMapFolder, fsstore.ConfigMapSeparator); ok {
+			key = volumeGroupKey{kind: "configmap", name: name, mountPath: path.Dir(mountPath)}
+			source = core.VolumeSource{
+				ConfigMap: &core.ConfigMapVolumeSource{
+					LocalObjectReference: core.LocalObjectReference{Name: name},
+				},
+			}
+		} else if name, ok := resolveStoreBind(hostPath, fsstore.SecretFolder, fsstore.SecretSeparator); ok {
+			key = volumeGroupKey{kind: "secret", name: name, mountPath: path.Dir(mountPath)}
+			source = core.VolumeSource{Secret: &core.SecretVolumeSource{SecretName: name}}
+		} else {
+			key = volumeGroupKey{kind: "hostpath", name: hostPath, mountPath: mountPath}
+			source = core.VolumeSource{HostPath: &core.HostPathVolumeSource{Path: hostPath}}
+		}
+
+		if _, exists := sources[key]; !exists {
+			order = append(order, key)
+			sources[key] = source
+		}
+	}
+
+	volumes := make([]core.Volume, 0, len(order))
+	volumeMounts := make([]core.VolumeMount, 0, len(order))
+	for i, key := range order {
+		volumeName := fmt.Sprintf("volume-%d", i)
+		volumes = append(volumes, core.Volume{Name: volumeName, VolumeSource: sources[key]})
+		volumeMounts = append(volumeMounts, core.VolumeMount{Name: volumeName, MountPath: key.mountPath})
+	}
+
+	return volumes, volumeMounts
+}
+
+// resolveStoreBind reports whether hostPath was generated by the FileSystemStore for the
+// given resource folder ("configmaps" or "secrets"), and if so returns the name of the
+// ConfigMap/Secret it belongs to.
+func resolveStoreBind(hostPath, folder, separator string) (string, bool) {
+	if !strings.Contains(hostPath, path.Clean(folder)) {
+		return "", false
+	}
+
+	fileName := path.Base(hostPath)
+	name, _, found := strings.Cut(fileName, separator)
+	if !found {
+		return "", false
+	}
+
+	return name, true
+}
+
+// generateContainerSecurityContext converts Docker's privileged flag back into a Kubernetes
+// SecurityContext. This is the inverse of the Privileged handling in setSecurityContext.
+func generateContainerSecurityContext(hostConfig *container.HostConfig, config *container.Config) *core.SecurityContext {
+	if hostConfig == nil || !hostConfig.Privileged {
+		return nil
+	}
+
+	privileged := true
+	return &core.SecurityContext{Privileged: &privileged}
+}
@@ -0,0 +1,98 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+func TestGenerateVolumes_GroupsMultiKeyConfigMapIntoOneVolumeMount(t *testing.T) {
+	binds := []string{
+		"/data/configmaps/default/app-k2dcm-key1:/etc/config/key1",
+		"/data/configmaps/default/app-k2dcm-key2:/etc/config/key2",
+	}
+
+	volumes, volumeMounts := generateVolumes(binds)
+
+	if len(volumes) != 1 {
+		t.Fatalf("generateVolumes returned %d volumes, want 1", len(volumes))
+	}
+
+	if len(volumeMounts) != 1 {
+		t.Fatalf("generateVolumes returned %d volumeMounts, want 1", len(volumeMounts))
+	}
+
+	if volumeMounts[0].MountPath != "/etc/config" {
+		t.Errorf("MountPath = %q, want %q", volumeMounts[0].MountPath, "/etc/config")
+	}
+
+	if volumes[0].VolumeSource.ConfigMap == nil || volumes[0].VolumeSource.ConfigMap.Name != "app" {
+		t.Errorf("VolumeSource.ConfigMap = %+v, want a ConfigMap volume for %q", volumes[0].VolumeSource.ConfigMap, "app")
+	}
+}
+
+func TestGenerateVolumes_DistinctConfigMapsStayDistinct(t *testing.T) {
+	binds := []string{
+		"/data/configmaps/default/app-k2dcm-key1:/etc/app/key1",
+		"/data/configmaps/default/other-k2dcm-key1:/etc/other/key1",
+	}
+
+	volumes, volumeMounts := generateVolumes(binds)
+
+	if len(volumes) != 2 || len(volumeMounts) != 2 {
+		t.Fatalf("generateVolumes returned %d volumes and %d volumeMounts, want 2 and 2", len(volumes), len(volumeMounts))
+	}
+}
+
+func TestGenerateVolumes_HostPath(t *testing.T) {
+	binds := []string{"/host/data:/container/data"}
+
+	volumes, volumeMounts := generateVolumes(binds)
+
+	if len(volumes) != 1 {
+		t.Fatalf("generateVolumes returned %d volumes, want 1", len(volumes))
+	}
+
+	if volumes[0].VolumeSource.HostPath == nil || volumes[0].VolumeSource.HostPath.Path != "/host/data" {
+		t.Errorf("VolumeSource.HostPath = %+v, want path %q", volumes[0].VolumeSource.HostPath, "/host/data")
+	}
+
+	if volumeMounts[0].MountPath != "/container/data" {
+		t.Errorf("MountPath = %q, want %q", volumeMounts[0].MountPath, "/container/data")
+	}
+}
+
+func TestGenerateResourceRequirements(t *testing.T) {
+	resources := container.Resources{
+		Memory:   256 * 1024 * 1024,
+		NanoCPUs: 1_500_000_000,
+	}
+
+	requirements := generateResourceRequirements(resources)
+
+	memory, ok := requirements.Limits[core.ResourceMemory]
+	if !ok {
+		t.Fatal("requirements.Limits is missing a memory entry")
+	}
+	if want := resource.MustParse("256Mi"); memory.Cmp(want) != 0 {
+		t.Errorf("memory limit = %s, want %s", memory.String(), want.String())
+	}
+
+	cpu, ok := requirements.Limits[core.ResourceCPU]
+	if !ok {
+		t.Fatal("requirements.Limits is missing a cpu entry")
+	}
+	if want := resource.MustParse("1500m"); cpu.Cmp(want) != 0 {
+		t.Errorf("cpu limit = %s, want %s", cpu.String(), want.String())
+	}
+}
+
+func TestGenerateResourceRequirements_NoLimits(t *testing.T) {
+	requirements := generateResourceRequirements(container.Resources{})
+
+	if requirements.Limits != nil {
+		t.Errorf("requirements.Limits = %+v, want nil", requirements.Limits)
+	}
+}
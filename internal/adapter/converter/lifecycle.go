@@ -0,0 +1,302 @@
+package converter
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/registry"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+// ContainerRuntime is the subset of the Docker client the Pod lifecycle needs in order to
+// create, start and remove containers. It is satisfied by *client.Client.
+type ContainerRuntime interface {
+	ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *ocispec.Platform, containerName string) (container.CreateResponse, error)
+	ContainerStart(ctx context.Context, containerID string, options types.ContainerStartOptions) error
+	ContainerWait(ctx context.Context, containerID string, condition container.WaitCondition) (<-chan container.WaitResponse, <-chan error)
+	ContainerRemove(ctx context.Context, containerID string, options types.ContainerRemoveOptions) error
+}
+
+// ImagePuller is the subset of the Docker client needed to pull a container's image before
+// creating it, authenticating with the registry credentials ResolveImagePullAuth resolves from
+// spec.ImagePullSecrets, and to inspect the pulled image's configured user so runAsNonRoot can
+// be validated against it via ValidateRunAsNonRoot.
+type ImagePuller interface {
+	ImagePull(ctx context.Context, refStr string, options types.ImagePullOptions) (io.ReadCloser, error)
+	ImageInspectWithRaw(ctx context.Context, imageID string) (types.ImageInspect, []byte, error)
+}
+
+// DockerClient is the full set of Docker client operations the Pod lifecycle needs.
+type DockerClient interface {
+	ContainerRuntime
+	ImagePuller
+}
+
+// CreatePod creates the Docker containers that back a Pod: its hidden infra container first,
+// via ConvertPodSpecToInfraContainerConfiguration; then, in declaration order, each of
+// spec.InitContainers, run serially to completion via ConvertPodSpecToInitContainerConfiguration
+// - the next one is only created once ContainerWait reports the previous one exited with code
+// 0; finally each of spec.Containers, via ConvertPodSpecToContainerConfiguration. The infra
+// container's pauseImage and every init/app container's image is pulled via ImagePull before it
+// is created, authenticated with the registry credentials ResolveImagePullAuth resolves from
+// spec.ImagePullSecrets. If any container fails to build, pull, create, start, or an init
+// container exits non-zero, every container already created for this Pod is removed before the
+// error is returned, so a failed Pod creation never leaves partial state behind. It returns the
+// infra container's ID.
+func (converter *DockerAPIConverter) CreatePod(ctx context.Context, cli DockerClient, podName, namespace string, spec core.PodSpec, annotations, labels map[string]string) (infraContainerID string, err error) {
+	var createdContainerIDs []string
+	defer func() {
+		if err != nil {
+			removeContainers(ctx, cli, createdContainerIDs)
+		}
+	}()
+
+	imagePullAuth, err := converter.ResolveImagePullAuth(namespace, spec)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve image pull secrets: %w", err)
+	}
+
+	if err := pullImage(ctx, cli, pauseImage, imagePullAuth); err != nil {
+		return "", fmt.Errorf("unable to pull infra container image: %w", err)
+	}
+
+	infraConfig, err := converter.ConvertPodSpecToInfraContainerConfiguration(podName, namespace, spec, labels)
+	if err != nil {
+		return "", fmt.Errorf("unable to build infra container configuration: %w", err)
+	}
+
+	infraContainerID, err = createAndStartContainer(ctx, cli, infraConfig, podName+infraContainerSuffix)
+	if err != nil {
+		return "", fmt.Errorf("unable to create infra container: %w", err)
+	}
+	createdContainerIDs = append(createdContainerIDs, infraContainerID)
+
+	for _, initContainerSpec := range spec.InitContainers {
+		if pullErr := pullImage(ctx, cli, initContainerSpec.Image, imagePullAuth); pullErr != nil {
+			err = fmt.Errorf("unable to pull image for init container %s: %w", initContainerSpec.Name, pullErr)
+			return "", err
+		}
+
+		initImageUser, inspectErr := imageUser(ctx, cli, initContainerSpec.Image)
+		if inspectErr != nil {
+			err = fmt.Errorf("unable to inspect image for init container %s: %w", initContainerSpec.Name, inspectErr)
+			return "", err
+		}
+
+		if validateErr := ValidateRunAsNonRoot(spec.SecurityContext, initContainerSpec.SecurityContext, initImageUser); validateErr != nil {
+			err = fmt.Errorf("init container %s: %w", initContainerSpec.Name, validateErr)
+			return "", err
+		}
+
+		initConfig, buildErr := converter.ConvertPodSpecToInitContainerConfiguration(namespace, annotations, spec, initContainerSpec, infraContainerID, labels)
+		if buildErr != nil {
+			err = fmt.Errorf("unable to build init container configuration for %s: %w", initContainerSpec.Name, buildErr)
+			return "", err
+		}
+
+		initContainerID, createErr := createAndStartContainer(ctx, cli, initConfig, fmt.Sprintf("%s-%s", podName, initContainerSpec.Name))
+		if createErr != nil {
+			err = fmt.Errorf("unable to create init container %s: %w", initContainerSpec.Name, createErr)
+			return "", err
+		}
+		createdContainerIDs = append(createdContainerIDs, initContainerID)
+
+		if waitErr := waitForInitContainer(ctx, cli, initContainerID); waitErr != nil {
+			err = fmt.Errorf("init container %s failed: %w", initContainerSpec.Name, waitErr)
+			return "", err
+		}
+	}
+
+	for _, containerSpec := range spec.Containers {
+		if pullErr := pullImage(ctx, cli, containerSpec.Image, imagePullAuth); pullErr != nil {
+			err = fmt.Errorf("unable to pull image for container %s: %w", containerSpec.Name, pullErr)
+			return "", err
+		}
+
+		containerImageUser, inspectErr := imageUser(ctx, cli, containerSpec.Image)
+		if inspectErr != nil {
+			err = fmt.Errorf("unable to inspect image for container %s: %w", containerSpec.Name, inspectErr)
+			return "", err
+		}
+
+		if validateErr := ValidateRunAsNonRoot(spec.SecurityContext, containerSpec.SecurityContext, containerImageUser); validateErr != nil {
+			err = fmt.Errorf("container %s: %w", containerSpec.Name, validateErr)
+			return "", err
+		}
+
+		containerConfig, buildErr := converter.ConvertPodSpecToContainerConfiguration(namespace, annotations, spec, containerSpec, infraContainerID, labels)
+		if buildErr != nil {
+			err = fmt.Errorf("unable to build container configuration for %s: %w", containerSpec.Name, buildErr)
+			return "", err
+		}
+
+		containerID, createErr := createAndStartContainer(ctx, cli, containerConfig, fmt.Sprintf("%s-%s", podName, containerSpec.Name))
+		if createErr != nil {
+			err = fmt.Errorf("unable to create container %s: %w", containerSpec.Name, createErr)
+			return "", err
+		}
+		createdContainerIDs = append(createdContainerIDs, containerID)
+	}
+
+	return infraContainerID, nil
+}
+
+// pullImage pulls image, authenticating with the credentials in imagePullAuth keyed by
+// registry server address, as resolved by ResolveImagePullAuth. It is a no-op-on-auth (an
+// anonymous pull) if image's registry isn't one of imagePullAuth's keys.
+func pullImage(ctx context.Context, puller ImagePuller, image string, imagePullAuth map[string]registry.AuthConfig) error {
+	options := types.ImagePullOptions{}
+
+	if auth, ok := imagePullAuth[registryServerFromImage(image)]; ok {
+		encodedAuth, err := encodeRegistryAuth(auth)
+		if err != nil {
+			return fmt.Errorf("unable to encode registry auth: %w", err)
+		}
+		options.RegistryAuth = encodedAuth
+	}
+
+	reader, err := puller.ImagePull(ctx, image, options)
+	if err != nil {
+		return fmt.Errorf("unable to pull image %s: %w", image, err)
+	}
+	defer reader.Close()
+
+	_, err = io.Copy(io.Discard, reader)
+	return err
+}
+
+// imageUser returns image's configured Config.User, so it can be passed to
+// ValidateRunAsNonRoot. It returns "" if the image declares no user, which
+// ValidateRunAsNonRoot treats as running as root.
+func imageUser(ctx context.Context, puller ImagePuller, image string) (string, error) {
+	inspect, _, err := puller.ImageInspectWithRaw(ctx, image)
+	if err != nil {
+		return "", fmt.Errorf("unable to inspect image %s: %w", image, err)
+	}
+
+	if inspect.Config == nil {
+		return "", nil
+	}
+
+	return inspect.Config.User, nil
+}
+
+// registryServerFromImage returns the registry server address an image reference pulls from,
+// or "" if it has none - i.e. it is a Docker Hub image, which ResolveImagePullAuth never keys
+// its result by.
+func registryServerFromImage(image string) string {
+	firstSegment, rest, found := strings.Cut(image, "/")
+	if !found {
+		return ""
+	}
+
+	if strings.ContainsAny(firstSegment, ".:") || firstSegment == "localhost" {
+		return firstSegment
+	}
+
+	_ = rest
+	return ""
+}
+
+// encodeRegistryAuth base64-encodes auth the way the Docker API expects it in
+// ImagePullOptions.RegistryAuth.
+func encodeRegistryAuth(auth registry.AuthConfig) (string, error) {
+	data, err := json.Marshal(auth)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(data), nil
+}
+
+// waitForInitContainer blocks until containerID - an already-started init container - exits,
+// returning an error if it exits with a non-zero code or ContainerWait itself fails.
+func waitForInitContainer(ctx context.Context, cli ContainerRuntime, containerID string) error {
+	statusCh, errCh := cli.ContainerWait(ctx, containerID, container.WaitConditionNotRunning)
+
+	select {
+	case waitErr := <-errCh:
+		return waitErr
+	case status := <-statusCh:
+		return initContainerExitError(status)
+	}
+}
+
+// initContainerExitError returns an error describing status if it reports a non-zero exit
+// code, and nil otherwise. It is split out from waitForInitContainer so the decision can be
+// tested without a real Docker client.
+func initContainerExitError(status container.WaitResponse) error {
+	if status.Error != nil && status.Error.Message != "" {
+		return fmt.Errorf("wait error: %s", status.Error.Message)
+	}
+
+	if status.StatusCode != 0 {
+		return fmt.Errorf("exited with code %d", status.StatusCode)
+	}
+
+	return nil
+}
+
+// DeletePod removes every Docker container belonging to a Pod - its user containers and its
+// hidden infra container, identified by the shared PodInfraContainerIDLabelKey label - as
+// reported by ConvertContainerToPod's containers argument. Sibling containers are removed
+// before the infra container they depend on.
+func (converter *DockerAPIConverter) DeletePod(ctx context.Context, cli ContainerRuntime, containers []types.Container) error {
+	infra, userContainers := splitInfraContainer(containers)
+
+	// removeContainers removes in reverse order, so the infra container goes first in the
+	// slice in order to be removed last.
+	containerIDs := make([]string, 0, len(containers))
+	if infra.ID != "" {
+		containerIDs = append(containerIDs, infra.ID)
+	}
+	for _, c := range userContainers {
+		containerIDs = append(containerIDs, c.ID)
+	}
+
+	return removeContainers(ctx, cli, containerIDs)
+}
+
+// createAndStartContainer creates a single Docker container from config and starts it,
+// returning its ID. If the container is created but fails to start, it is force-removed
+// before the error is returned.
+func createAndStartContainer(ctx context.Context, cli ContainerRuntime, config ContainerConfiguration, containerName string) (string, error) {
+	resp, err := cli.ContainerCreate(ctx, config.ContainerConfig, config.HostConfig, config.NetworkConfig, nil, containerName)
+	if err != nil {
+		return "", err
+	}
+
+	if err := cli.ContainerStart(ctx, resp.ID, types.ContainerStartOptions{}); err != nil {
+		if removeErr := cli.ContainerRemove(ctx, resp.ID, types.ContainerRemoveOptions{Force: true}); removeErr != nil {
+			return "", errors.Join(err, fmt.Errorf("unable to remove container %s: %w", resp.ID, removeErr))
+		}
+		return "", err
+	}
+
+	return resp.ID, nil
+}
+
+// removeContainers force-removes containerIDs in reverse order, so a sibling container
+// created after the infra container (which is always first in the slice) is removed before
+// it. It collects every removal error rather than stopping at the first one, since a rollback
+// should make a best effort to clean up as much as it can.
+func removeContainers(ctx context.Context, cli ContainerRuntime, containerIDs []string) error {
+	var errs []error
+
+	for i := len(containerIDs) - 1; i >= 0; i-- {
+		if err := cli.ContainerRemove(ctx, containerIDs[i], types.ContainerRemoveOptions{Force: true}); err != nil {
+			errs = append(errs, fmt.Errorf("unable to remove container %s: %w", containerIDs[i], err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,159 @@
+package converter
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/registry"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+func TestPodPhase(t *testing.T) {
+	tests := []struct {
+		name             string
+		allInitSucceeded bool
+		allRunning       bool
+		anyRunning       bool
+		want             core.PodPhase
+	}{
+		{"init not yet succeeded", false, true, true, core.PodPending},
+		{"init not yet succeeded and nothing running", false, false, false, core.PodPending},
+		{"all containers running", true, true, true, core.PodRunning},
+		{"some containers running", true, false, true, core.PodRunning},
+		{"nothing running", true, false, false, core.PodUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := podPhase(tt.allInitSucceeded, tt.allRunning, tt.anyRunning); got != tt.want {
+				t.Errorf("podPhase(%t, %t, %t) = %s, want %s", tt.allInitSucceeded, tt.allRunning, tt.anyRunning, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInitContainerExitError(t *testing.T) {
+	if err := initContainerExitError(container.WaitResponse{StatusCode: 0}); err != nil {
+		t.Errorf("initContainerExitError(exit 0) = %v, want nil", err)
+	}
+
+	if err := initContainerExitError(container.WaitResponse{StatusCode: 1}); err == nil {
+		t.Error("initContainerExitError(exit 1) = nil, want an error")
+	}
+
+	waitErr := initContainerExitError(container.WaitResponse{Error: &container.WaitExitError{Message: "boom"}})
+	if waitErr == nil {
+		t.Error("initContainerExitError(wait error) = nil, want an error")
+	}
+}
+
+func TestRegistryServerFromImage(t *testing.T) {
+	tests := []struct {
+		name  string
+		image string
+		want  string
+	}{
+		{"docker hub image with no namespace", "nginx:latest", ""},
+		{"docker hub image with namespace", "library/nginx:latest", ""},
+		{"private registry with port", "registry.example.com:5000/team/app:v1", "registry.example.com:5000"},
+		{"private registry without port", "registry.example.com/team/app:v1", "registry.example.com"},
+		{"localhost registry", "localhost/team/app:v1", "localhost"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := registryServerFromImage(tt.image); got != tt.want {
+				t.Errorf("registryServerFromImage(%q) = %q, want %q", tt.image, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeRegistryAuth(t *testing.T) {
+	encoded, err := encodeRegistryAuth(registry.AuthConfig{Username: "user", Password: "pass"})
+	if err != nil {
+		t.Fatalf("encodeRegistryAuth returned an error: %v", err)
+	}
+
+	if encoded == "" {
+		t.Error("encodeRegistryAuth returned an empty string")
+	}
+}
+
+type fakeImagePuller struct {
+	pulledImage   string
+	pulledOptions types.ImagePullOptions
+	inspectUser   string
+	inspectErr    error
+}
+
+func (f *fakeImagePuller) ImagePull(_ context.Context, refStr string, options types.ImagePullOptions) (io.ReadCloser, error) {
+	f.pulledImage = refStr
+	f.pulledOptions = options
+	return io.NopCloser(strings.NewReader("")), nil
+}
+
+func (f *fakeImagePuller) ImageInspectWithRaw(_ context.Context, _ string) (types.ImageInspect, []byte, error) {
+	if f.inspectErr != nil {
+		return types.ImageInspect{}, nil, f.inspectErr
+	}
+
+	return types.ImageInspect{Config: &container.Config{User: f.inspectUser}}, nil, nil
+}
+
+func TestPullImage_UsesMatchingRegistryAuth(t *testing.T) {
+	puller := &fakeImagePuller{}
+	auths := map[string]registry.AuthConfig{
+		"registry.example.com": {Username: "user", Password: "pass"},
+	}
+
+	if err := pullImage(context.Background(), puller, "registry.example.com/team/app:v1", auths); err != nil {
+		t.Fatalf("pullImage returned an error: %v", err)
+	}
+
+	if puller.pulledOptions.RegistryAuth == "" {
+		t.Error("pullImage did not set RegistryAuth for a registry with matching credentials")
+	}
+}
+
+func TestPullImage_NoAuthForUnknownRegistry(t *testing.T) {
+	puller := &fakeImagePuller{}
+
+	if err := pullImage(context.Background(), puller, "nginx:latest", nil); err != nil {
+		t.Fatalf("pullImage returned an error: %v", err)
+	}
+
+	if puller.pulledOptions.RegistryAuth != "" {
+		t.Errorf("pullImage set RegistryAuth = %q, want empty for an unauthenticated pull", puller.pulledOptions.RegistryAuth)
+	}
+}
+
+func TestImageUser(t *testing.T) {
+	puller := &fakeImagePuller{inspectUser: "1000"}
+
+	got, err := imageUser(context.Background(), puller, "app:v1")
+	if err != nil {
+		t.Fatalf("imageUser returned an error: %v", err)
+	}
+
+	if got != "1000" {
+		t.Errorf("imageUser() = %q, want %q", got, "1000")
+	}
+}
+
+func TestImageUser_NoUserConfigured(t *testing.T) {
+	puller := &fakeImagePuller{}
+
+	got, err := imageUser(context.Background(), puller, "app:v1")
+	if err != nil {
+		t.Fatalf("imageUser returned an error: %v", err)
+	}
+
+	if got != "" {
+		t.Errorf("imageUser() = %q, want empty string", got)
+	}
+}
@@ -11,16 +11,69 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/go-connections/nat"
+	fsstore "github.com/portainer/k2d/internal/adapter/store/filesystem"
 	k2dtypes "github.com/portainer/k2d/internal/adapter/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kubernetes/pkg/apis/core"
 )
 
-// ConvertContainerToPod tries to convert a Docker container into a Kubernetes Pod.
+const (
+	// PodInfraContainerIDLabelKey is set on every container that belongs to a Pod - the
+	// infra container itself and every sibling user container - to the ID of that Pod's
+	// infra container. It is what lets ConvertContainerToPod group sibling containers
+	// back into a single Pod.
+	PodInfraContainerIDLabelKey = "k2d.io/pod.infra-container-id"
+
+	// PodContainerNameLabelKey stores the container's name as declared in the Pod spec,
+	// since the Docker container name is derived from the Pod name and can't be reused
+	// for more than one container.
+	PodContainerNameLabelKey = "k2d.io/pod.container-name"
+
+	// PodInfraContainerLabelKey marks a container as being the hidden infra ("pause")
+	// container created for a Pod, as opposed to one of its user containers.
+	PodInfraContainerLabelKey = "k2d.io/pod.infra-container"
+
+	// PodContainerTypeLabelKey records whether a user container was declared under
+	// spec.containers or spec.initContainers, so ConvertContainerToPod can put it back in
+	// the right place. Containers created before init container support was added won't
+	// carry this label and are treated as containerTypeApp.
+	PodContainerTypeLabelKey = "k2d.io/pod.container-type"
+
+	containerTypeApp  = "app"
+	containerTypeInit = "init"
+
+	// PodNamespaceLabelKey records the namespace the Pod was created in, since Docker has
+	// no notion of namespaces of its own.
+	PodNamespaceLabelKey = "k2d.io/pod.namespace"
+
+	// infraContainerSuffix is appended to the Pod name to derive the name of its hidden
+	// infra container.
+	infraContainerSuffix = "-infra"
+
+	// pauseImage is the image used for a Pod's infra container. It only needs to hold
+	// open the namespaces shared by the Pod's user containers, so it never runs any
+	// user-supplied command.
+	pauseImage = "registry.k8s.io/pause:3.9"
+)
+
+// ConvertContainerToPod tries to convert the Docker containers that belong to a single Pod
+// into a Kubernetes Pod. containers must contain every sibling container of that Pod - its
+// hidden infra container plus each user container - as identified by a shared
+// PodInfraContainerIDLabelKey label; the infra container itself is never surfaced in the
+// resulting Pod, it only lends the Pod its name, creation timestamp, namespace and
+// last-applied-configuration annotation.
 // It only implements partial conversion at the moment.
-func (converter *DockerAPIConverter) ConvertContainerToPod(container types.Container) core.Pod {
-	containerName := strings.TrimPrefix(container.Names[0], "/")
-	containerState := container.State
+func (converter *DockerAPIConverter) ConvertContainerToPod(containers []types.Container) core.Pod {
+	infra, userContainers := splitInfraContainer(containers)
+	reference := infra
+	if reference.ID == "" && len(userContainers) > 0 {
+		reference = userContainers[0]
+	}
+
+	podName := strings.TrimSuffix(strings.TrimPrefix(reference.Names[0], "/"), infraContainerSuffix)
+	if infra.ID != "" {
+		podName = strings.TrimSuffix(strings.TrimPrefix(infra.Names[0], "/"), infraContainerSuffix)
+	}
 
 	pod := core.Pod{
 		TypeMeta: metav1.TypeMeta{
@@ -28,136 +81,281 @@ func (converter *DockerAPIConverter) ConvertContainerToPod(container types.Conta
 			APIVersion: "v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:              containerName,
-			CreationTimestamp: metav1.NewTime(time.Unix(container.Created, 0)),
-			Namespace:         "default",
+			Name:              podName,
+			CreationTimestamp: metav1.NewTime(time.Unix(reference.Created, 0)),
+			Namespace:         namespaceOrDefault(reference.Labels[PodNamespaceLabelKey]),
 			Annotations: map[string]string{
-				"kubectl.kubernetes.io/last-applied-configuration": container.Labels[k2dtypes.WorkloadLastAppliedConfigLabelKey],
-			},
-		},
-		Spec: core.PodSpec{
-			Containers: []core.Container{
-				{
-					Name:  containerName,
-					Image: container.Image,
-				},
-			},
-		},
-		Status: core.PodStatus{
-			ContainerStatuses: []core.ContainerStatus{
-				{
-					Name:         containerName,
-					ContainerID:  container.ID,
-					RestartCount: 0,
-				},
+				"kubectl.kubernetes.io/last-applied-configuration": reference.Labels[k2dtypes.WorkloadLastAppliedConfigLabelKey],
 			},
 		},
 	}
 
-	if containerState == "running" {
-		ready := true
+	allRunning := false
+	anyRunning := false
+	allInitSucceeded := true
 
-		pod.Status.Phase = core.PodRunning
+	for _, c := range userContainers {
+		containerName := c.Labels[PodContainerNameLabelKey]
+		if containerName == "" {
+			containerName = strings.TrimPrefix(c.Names[0], "/")
+		}
 
-		pod.Status.ContainerStatuses[0].Ready = ready
-		pod.Status.ContainerStatuses[0].Started = &ready
+		if c.Labels[PodContainerTypeLabelKey] == containerTypeInit {
+			status := core.ContainerStatus{
+				Name:        containerName,
+				ContainerID: c.ID,
+			}
 
-		pod.Status.ContainerStatuses[0].State.Running = &core.ContainerStateRunning{
-			StartedAt: metav1.NewTime(time.Unix(container.Created, 0)),
+			exitCode := exitCodeFromStatus(c.Status)
+			if c.State == "exited" {
+				if exitCode != 0 {
+					allInitSucceeded = false
+				}
+				status.State.Terminated = &core.ContainerStateTerminated{
+					ExitCode:    int32(exitCode),
+					ContainerID: c.ID,
+				}
+			} else {
+				allInitSucceeded = false
+				status.State.Waiting = &core.ContainerStateWaiting{}
+			}
+
+			pod.Spec.InitContainers = append(pod.Spec.InitContainers, core.Container{
+				Name:  containerName,
+				Image: c.Image,
+			})
+			pod.Status.InitContainerStatuses = append(pod.Status.InitContainerStatuses, status)
+			continue
+		}
+
+		pod.Spec.Containers = append(pod.Spec.Containers, core.Container{
+			Name:  containerName,
+			Image: c.Image,
+		})
+
+		status := core.ContainerStatus{
+			Name:         containerName,
+			ContainerID:  c.ID,
+			RestartCount: 0,
 		}
-	} else {
-		pod.Status.Phase = core.PodUnknown
+
+		if c.State == "running" {
+			ready := true
+			anyRunning = true
+			allRunning = true
+
+			status.Ready = ready
+			status.Started = &ready
+			status.State.Running = &core.ContainerStateRunning{
+				StartedAt: metav1.NewTime(time.Unix(c.Created, 0)),
+			}
+		} else {
+			allRunning = false
+		}
+
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, status)
 	}
 
+	pod.Status.Phase = podPhase(allInitSucceeded, allRunning, anyRunning)
+
 	return pod
 }
 
-// ConvertPodSpecToContainerConfiguration converts a Kubernetes PodSpec into a Docker container configuration.
-// It receives a Kubernetes PodSpec and a map of labels.
+// podPhase derives a Pod's phase from the state of its init and app containers. Init
+// containers run to completion before any app container starts, so an init container that
+// hasn't yet succeeded keeps the Pod Pending regardless of what its app containers are doing.
+func podPhase(allInitSucceeded, allRunning, anyRunning bool) core.PodPhase {
+	switch {
+	case !allInitSucceeded:
+		return core.PodPending
+	case allRunning || anyRunning:
+		return core.PodRunning
+	default:
+		return core.PodUnknown
+	}
+}
+
+// exitCodeFromStatus extracts the numeric exit code Docker reports in a container's Status
+// string (e.g. "Exited (1) 2 minutes ago"). It returns -1 if no exit code could be parsed.
+func exitCodeFromStatus(status string) int {
+	start := strings.Index(status, "(")
+	end := strings.Index(status, ")")
+	if start == -1 || end == -1 || end < start {
+		return -1
+	}
+
+	code, err := strconv.Atoi(status[start+1 : end])
+	if err != nil {
+		return -1
+	}
+
+	return code
+}
+
+// splitInfraContainer separates a Pod's hidden infra container from its user containers.
+// containers that were created before infra/pause support was added won't carry the
+// PodInfraContainerLabelKey label, in which case infra is returned as the zero value and
+// every container is treated as a user container.
+func splitInfraContainer(containers []types.Container) (infra types.Container, userContainers []types.Container) {
+	for _, c := range containers {
+		if _, ok := c.Labels[PodInfraContainerLabelKey]; ok {
+			infra = c
+			continue
+		}
+
+		userContainers = append(userContainers, c)
+	}
+
+	return infra, userContainers
+}
+
+// namespaceOrDefault returns namespace, or filesystem.DefaultNamespace if it is empty - e.g.
+// for containers created before namespace support was added.
+func namespaceOrDefault(namespace string) string {
+	if namespace == "" {
+		return fsstore.DefaultNamespace
+	}
+
+	return namespace
+}
+
+// ConvertPodSpecToInfraContainerConfiguration builds the Docker container configuration for a
+// Pod's hidden infra ("pause") container. The infra container owns the Pod's hostname and
+// port bindings and exists solely to hold open the network, IPC and, when
+// spec.ShareProcessNamespace is true, PID namespaces that every user container of the Pod
+// joins. It must be created and started before any of the Pod's other containers, and its
+// resulting container ID must be passed to ConvertPodSpecToContainerConfiguration for each of
+// them.
+func (converter *DockerAPIConverter) ConvertPodSpecToInfraContainerConfiguration(podName, namespace string, spec core.PodSpec, labels map[string]string) (ContainerConfiguration, error) {
+	infraLabels := map[string]string{}
+	for k, v := range labels {
+		infraLabels[k] = v
+	}
+	infraLabels[PodInfraContainerLabelKey] = "true"
+	infraLabels[PodNamespaceLabelKey] = namespaceOrDefault(namespace)
+
+	containerConfig := &container.Config{
+		Image:    pauseImage,
+		Hostname: podName,
+		Labels:   infraLabels,
+	}
+
+	hostConfig := &container.HostConfig{
+		ExtraHosts: []string{
+			fmt.Sprintf("kubernetes.default.svc:%s", converter.k2dServerConfiguration.ServerIpAddr),
+		},
+	}
+
+	var ports []core.ContainerPort
+	for _, containerSpec := range spec.Containers {
+		ports = append(ports, containerSpec.Ports...)
+	}
+
+	if err := converter.setHostPorts(containerConfig, hostConfig, ports); err != nil {
+		return ContainerConfiguration{}, err
+	}
+
+	return ContainerConfiguration{
+		ContainerConfig: containerConfig,
+		HostConfig:      hostConfig,
+		NetworkConfig: &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				k2dtypes.K2DNetworkName: {},
+			},
+		},
+	}, nil
+}
+
+// ConvertPodSpecToContainerConfiguration converts a single Kubernetes container - together
+// with the rest of the Pod spec it belongs to - into a Docker container configuration that
+// joins the namespaces of the Pod's infra container identified by infraContainerID. See
+// ConvertPodSpecToInfraContainerConfiguration.
+// It receives the Pod's namespace, its annotations (used to resolve the legacy seccomp/
+// AppArmor annotations), the Pod spec, the container within it to convert, the infra
+// container's ID, and a map of labels.
 // It returns a ContainerConfiguration struct, or an error if the conversion fails.
-func (converter *DockerAPIConverter) ConvertPodSpecToContainerConfiguration(spec core.PodSpec, labels map[string]string) (ContainerConfiguration, error) {
-	containerSpec := spec.Containers[0]
+func (converter *DockerAPIConverter) ConvertPodSpecToContainerConfiguration(namespace string, annotations map[string]string, spec core.PodSpec, containerSpec core.Container, infraContainerID string, labels map[string]string) (ContainerConfiguration, error) {
+	namespace = namespaceOrDefault(namespace)
+
+	containerLabels := map[string]string{}
+	for k, v := range labels {
+		containerLabels[k] = v
+	}
+	containerLabels[PodContainerNameLabelKey] = containerSpec.Name
+	containerLabels[PodNamespaceLabelKey] = namespace
+	if _, ok := containerLabels[PodContainerTypeLabelKey]; !ok {
+		containerLabels[PodContainerTypeLabelKey] = containerTypeApp
+	}
 
 	containerConfig := &container.Config{
 		Image:  containerSpec.Image,
-		Labels: labels,
+		Labels: containerLabels,
 		Env: []string{
 			fmt.Sprintf("KUBERNETES_SERVICE_HOST=%s", converter.k2dServerConfiguration.ServerIpAddr),
 			fmt.Sprintf("KUBERNETES_SERVICE_PORT=%d", converter.k2dServerConfiguration.ServerPort),
 		},
 	}
 
+	joinInfraContainer := container.NetworkMode(fmt.Sprintf("container:%s", infraContainerID))
+
 	hostConfig := &container.HostConfig{
-		ExtraHosts: []string{
-			fmt.Sprintf("kubernetes.default.svc:%s", converter.k2dServerConfiguration.ServerIpAddr),
-		},
+		NetworkMode: joinInfraContainer,
+		IpcMode:     container.IpcMode(joinInfraContainer),
+		UTSMode:     container.UTSMode(joinInfraContainer),
 	}
 
-	if err := converter.setServiceAccountTokenAndCACert(hostConfig); err != nil {
-		return ContainerConfiguration{}, err
+	if spec.ShareProcessNamespace != nil && *spec.ShareProcessNamespace {
+		hostConfig.PidMode = container.PidMode(joinInfraContainer)
 	}
 
-	if err := converter.setHostPorts(containerConfig, hostConfig, containerSpec.Ports); err != nil {
+	if err := converter.setServiceAccountTokenAndCACert(hostConfig, namespace, spec.ServiceAccountName); err != nil {
 		return ContainerConfiguration{}, err
 	}
 
-	if err := converter.setEnvVars(containerConfig, containerSpec.Env, containerSpec.EnvFrom); err != nil {
+	if err := converter.setEnvVars(containerConfig, namespace, containerSpec.Env, containerSpec.EnvFrom); err != nil {
 		return ContainerConfiguration{}, err
 	}
 
 	setCommandAndArgs(containerConfig, containerSpec.Command, containerSpec.Args)
 	setRestartPolicy(hostConfig, spec.RestartPolicy)
-	setSecurityContext(containerConfig, hostConfig, spec.SecurityContext, containerSpec.SecurityContext)
-	converter.setResourceRequirements(hostConfig, containerSpec.Resources)
+	converter.setSecurityContext(containerConfig, hostConfig, annotations, containerSpec.Name, spec.SecurityContext, containerSpec.SecurityContext)
+	setResourceRequirements(containerConfig, hostConfig, containerSpec.Resources)
 
-	if err := converter.setVolumeMounts(hostConfig, spec.Volumes, containerSpec.VolumeMounts); err != nil {
+	if err := converter.setVolumeMounts(hostConfig, namespace, spec.Volumes, containerSpec.VolumeMounts); err != nil {
 		return ContainerConfiguration{}, err
 	}
 
 	return ContainerConfiguration{
 		ContainerConfig: containerConfig,
 		HostConfig:      hostConfig,
-		NetworkConfig: &network.NetworkingConfig{
-			EndpointsConfig: map[string]*network.EndpointSettings{
-				k2dtypes.K2DNetworkName: {},
-			},
-		},
 	}, nil
 }
 
-// setResourceRequirements configures the Docker container's resource constraints based on the provided core.ResourceRequirements.
-// It receives a Docker HostConfig and a Kubernetes ResourceRequirements.
-// It returns nothing.
-func (converter *DockerAPIConverter) setResourceRequirements(hostConfig *container.HostConfig, resources core.ResourceRequirements) {
-	resourceRequirements := container.Resources{}
-	if resources.Requests != nil {
-		for resourceName, quantity := range resources.Requests {
-			switch resourceName {
-			case core.ResourceCPU:
-				resourceRequirements.CPUShares = int64(quantity.MilliValue())
-			case core.ResourceMemory:
-				resourceRequirements.MemoryReservation = int64(quantity.Value())
-			}
-		}
+// ConvertPodSpecToInitContainerConfiguration builds the Docker container configuration for
+// one of the Pod's init containers. It reuses ConvertPodSpecToContainerConfiguration for env,
+// volumes and security context, then overrides the restart policy: init containers are run to
+// completion exactly once, in declaration order, before any app container starts, so Docker
+// must never restart them on its own.
+func (converter *DockerAPIConverter) ConvertPodSpecToInitContainerConfiguration(namespace string, annotations map[string]string, spec core.PodSpec, initContainerSpec core.Container, infraContainerID string, labels map[string]string) (ContainerConfiguration, error) {
+	initLabels := map[string]string{}
+	for k, v := range labels {
+		initLabels[k] = v
 	}
+	initLabels[PodContainerTypeLabelKey] = containerTypeInit
 
-	if resources.Limits != nil {
-		for resourceName, quantity := range resources.Limits {
-			switch resourceName {
-			case core.ResourceCPU:
-				resourceRequirements.NanoCPUs = int64(quantity.MilliValue()) * 1000000
-			case core.ResourceMemory:
-				resourceRequirements.Memory = int64(quantity.Value())
-			}
-		}
+	containerConfiguration, err := converter.ConvertPodSpecToContainerConfiguration(namespace, annotations, spec, initContainerSpec, infraContainerID, initLabels)
+	if err != nil {
+		return ContainerConfiguration{}, err
 	}
 
-	hostConfig.Resources = resourceRequirements
+	containerConfiguration.HostConfig.RestartPolicy = container.RestartPolicy{Name: "no"}
+
+	return containerConfiguration, nil
 }
 
 // setServiceAccountTokenAndCACert configures the Docker container to have access to the service account token
 // and CA certificate stored in a Kubernetes Secret. The function performs the following steps:
-//  1. Fetches the service account Secret from Kubernetes using the provided secretStore.
+//  1. Resolves the kubernetes.io/service-account-token Secret for the Pod's serviceAccountName.
 //  2. Obtains the filesystem bind mappings for the Secret using the secretStore's GetSecretBinds method.
 //  3. Modifies the hostConfig's Binds field to include the service account token and CA certificate by
 //     mapping the host file system paths to the container's "/var/run/secrets/kubernetes.io/serviceaccount/" directory.
@@ -165,17 +363,20 @@ func (converter *DockerAPIConverter) setResourceRequirements(hostConfig *contain
 // Parameters:
 //   - hostConfig: The Docker container's host configuration that will be modified to include the service
 //     account token and CA certificate binds.
+//   - serviceAccountName: the Pod's spec.ServiceAccountName, or "" to use the default service account.
 //
 // It returns an error if any occurred fetching the Secret or obtaining the bind mappings fails.
-func (converter *DockerAPIConverter) setServiceAccountTokenAndCACert(hostConfig *container.HostConfig) error {
-	secret, err := converter.secretStore.GetSecret(k2dtypes.K2dServiceAccountSecretName)
+func (converter *DockerAPIConverter) setServiceAccountTokenAndCACert(hostConfig *container.HostConfig, namespace, serviceAccountName string) error {
+	secretName := serviceAccountSecretName(serviceAccountName)
+
+	secret, err := converter.secretStore.GetSecret(secretName, namespace)
 	if err != nil {
-		return fmt.Errorf("unable to get secret %s: %w", k2dtypes.K2dServiceAccountSecretName, err)
+		return fmt.Errorf("unable to get secret %s: %w", secretName, err)
 	}
 
 	binds, err := converter.secretStore.GetSecretBinds(secret)
 	if err != nil {
-		return fmt.Errorf("unable to get binds for secrets %s: %w", k2dtypes.K2dServiceAccountSecretName, err)
+		return fmt.Errorf("unable to get binds for secrets %s: %w", secretName, err)
 	}
 
 	for containerBind, hostBind := range binds {
@@ -221,11 +422,11 @@ func (converter *DockerAPIConverter) setHostPorts(containerConfig *container.Con
 // setEnvVars handles setting the environment variables for the Docker container configuration.
 // It receives a pointer to the container configuration and an array of Kubernetes environment variables.
 // It returns an error if the setting of environment variables fails.
-func (converter *DockerAPIConverter) setEnvVars(containerConfig *container.Config, envs []core.EnvVar, envFrom []core.EnvFromSource) error {
+func (converter *DockerAPIConverter) setEnvVars(containerConfig *container.Config, namespace string, envs []core.EnvVar, envFrom []core.EnvFromSource) error {
 	for _, env := range envs {
 
 		if env.ValueFrom != nil {
-			if err := converter.handleValueFromEnvVars(containerConfig, env); err != nil {
+			if err := converter.handleValueFromEnvVars(containerConfig, namespace, env); err != nil {
 				return err
 			}
 		} else {
@@ -234,7 +435,7 @@ func (converter *DockerAPIConverter) setEnvVars(containerConfig *container.Confi
 	}
 
 	for _, env := range envFrom {
-		if err := converter.handleValueFromEnvFromSource(containerConfig, env); err != nil {
+		if err := converter.handleValueFromEnvFromSource(containerConfig, namespace, env); err != nil {
 			return err
 		}
 	}
@@ -252,9 +453,9 @@ func (converter *DockerAPIConverter) setEnvVars(containerConfig *container.Confi
 // If the EnvFromSource object points to a ConfigMap, the function retrieves the ConfigMap and adds its data as
 // environment variables to the Docker container configuration. Similarly, if the EnvFromSource points to a Secret,
 // the function retrieves the Secret and adds its data as environment variables.
-func (converter *DockerAPIConverter) handleValueFromEnvFromSource(containerConfig *container.Config, env core.EnvFromSource) error {
+func (converter *DockerAPIConverter) handleValueFromEnvFromSource(containerConfig *container.Config, namespace string, env core.EnvFromSource) error {
 	if env.ConfigMapRef != nil {
-		configMap, err := converter.configMapStore.GetConfigMap(env.ConfigMapRef.Name)
+		configMap, err := converter.configMapStore.GetConfigMap(env.ConfigMapRef.Name, namespace)
 		if err != nil {
 			return fmt.Errorf("unable to get configmap %s: %w", env.ConfigMapRef.Name, err)
 		}
@@ -263,7 +464,7 @@ func (converter *DockerAPIConverter) handleValueFromEnvFromSource(containerConfi
 			containerConfig.Env = append(containerConfig.Env, fmt.Sprintf("%s=%s", key, value))
 		}
 	} else if env.SecretRef != nil {
-		secret, err := converter.secretStore.GetSecret(env.SecretRef.Name)
+		secret, err := converter.secretStore.GetSecret(env.SecretRef.Name, namespace)
 		if err != nil {
 			return fmt.Errorf("unable to get secret %s: %w", env.SecretRef.Name, err)
 		}
@@ -279,16 +480,16 @@ func (converter *DockerAPIConverter) handleValueFromEnvFromSource(containerConfi
 // handleValueFromEnvVars manages environment variables that are defined through ConfigMap or Secret references.
 // It receives a pointer to the container configuration and a Kubernetes environment variable.
 // It returns an error if the sourcing of the environment variables fails.
-func (converter *DockerAPIConverter) handleValueFromEnvVars(containerConfig *container.Config, env core.EnvVar) error {
+func (converter *DockerAPIConverter) handleValueFromEnvVars(containerConfig *container.Config, namespace string, env core.EnvVar) error {
 	if env.ValueFrom.ConfigMapKeyRef != nil {
-		configMap, err := converter.configMapStore.GetConfigMap(env.ValueFrom.ConfigMapKeyRef.Name)
+		configMap, err := converter.configMapStore.GetConfigMap(env.ValueFrom.ConfigMapKeyRef.Name, namespace)
 		if err != nil {
 			return fmt.Errorf("unable to get configmap %s: %w", env.ValueFrom.ConfigMapKeyRef.Name, err)
 		}
 
 		containerConfig.Env = append(containerConfig.Env, fmt.Sprintf("%s=%s", env.Name, configMap.Data[env.ValueFrom.ConfigMapKeyRef.Key]))
 	} else if env.ValueFrom.SecretKeyRef != nil {
-		secret, err := converter.secretStore.GetSecret(env.ValueFrom.SecretKeyRef.Name)
+		secret, err := converter.secretStore.GetSecret(env.ValueFrom.SecretKeyRef.Name, namespace)
 		if err != nil {
 			return fmt.Errorf("unable to get secret %s: %w", env.ValueFrom.SecretKeyRef.Name, err)
 		}
@@ -324,35 +525,15 @@ func setCommandAndArgs(containerConfig *container.Config, command []string, args
 	}
 }
 
-// setSecurityContext sets the user and group ID in the Docker container configuration based on the provided
-// Kubernetes PodSecurityContext.
-// If no security context is provided, the function does not modify the container configuration.
-func setSecurityContext(config *container.Config, hostConfig *container.HostConfig, podSecurityContext *core.PodSecurityContext, containerSecurityContext *core.SecurityContext) {
-	if podSecurityContext == nil {
-		return
-	}
-
-	if podSecurityContext.RunAsUser != nil && podSecurityContext.RunAsGroup != nil {
-		config.User = fmt.Sprintf("%d:%d", *podSecurityContext.RunAsUser, *podSecurityContext.RunAsGroup)
-	}
-
-	if containerSecurityContext == nil {
-		return
-	}
-
-	if containerSecurityContext.Privileged != nil {
-		hostConfig.Privileged = *containerSecurityContext.Privileged
-	}
-}
 
 // setVolumeMounts manages volume mounts for the Docker container.
 // It receives a pointer to the host configuration, an array of Kubernetes volumes, and an array of Kubernetes volume mounts.
 // It returns an error if the handling of volume mounts fails.
-func (converter *DockerAPIConverter) setVolumeMounts(hostConfig *container.HostConfig, volumes []core.Volume, volumeMounts []core.VolumeMount) error {
+func (converter *DockerAPIConverter) setVolumeMounts(hostConfig *container.HostConfig, namespace string, volumes []core.Volume, volumeMounts []core.VolumeMount) error {
 	for _, volume := range volumes {
 		for _, volumeMount := range volumeMounts {
 			if volumeMount.Name == volume.Name {
-				if err := converter.handleVolumeSource(hostConfig, volume, volumeMount); err != nil {
+				if err := converter.handleVolumeSource(hostConfig, namespace, volume, volumeMount); err != nil {
 					return err
 				}
 				break
@@ -380,9 +561,9 @@ func (converter *DockerAPIConverter) setVolumeMounts(hostConfig *container.HostC
 //
 // Returns:
 // An error if fetching the ConfigMap or Secret from the store fails; otherwise, it returns nil.
-func (converter *DockerAPIConverter) handleVolumeSource(hostConfig *container.HostConfig, volume core.Volume, volumeMount core.VolumeMount) error {
+func (converter *DockerAPIConverter) handleVolumeSource(hostConfig *container.HostConfig, namespace string, volume core.Volume, volumeMount core.VolumeMount) error {
 	if volume.VolumeSource.ConfigMap != nil {
-		configMap, err := converter.configMapStore.GetConfigMap(volume.VolumeSource.ConfigMap.Name)
+		configMap, err := converter.configMapStore.GetConfigMap(volume.VolumeSource.ConfigMap.Name, namespace)
 		if err != nil {
 			return fmt.Errorf("unable to get configmap %s: %w", volume.VolumeSource.ConfigMap.Name, err)
 		}
@@ -397,11 +578,17 @@ func (converter *DockerAPIConverter) handleVolumeSource(hostConfig *container.Ho
 			hostConfig.Binds = append(hostConfig.Binds, bind)
 		}
 	} else if volume.VolumeSource.Secret != nil {
-		secret, err := converter.secretStore.GetSecret(volume.VolumeSource.Secret.SecretName)
+		secret, err := converter.secretStore.GetSecret(volume.VolumeSource.Secret.SecretName, namespace)
 		if err != nil {
 			return fmt.Errorf("unable to get secret %s: %w", volume.VolumeSource.Secret.SecretName, err)
 		}
 
+		if secret.Type == core.SecretTypeTLS {
+			if err := validateTLSSecret(secret); err != nil {
+				return err
+			}
+		}
+
 		binds, err := converter.secretStore.GetSecretBinds(secret)
 		if err != nil {
 			return fmt.Errorf("unable to get binds for secrets %s: %w", volume.VolumeSource.ConfigMap.Name, err)
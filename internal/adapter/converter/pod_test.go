@@ -0,0 +1,60 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestExitCodeFromStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   int
+	}{
+		{"Exited (0) 2 minutes ago", 0},
+		{"Exited (1) 5 seconds ago", 1},
+		{"Exited (137) 1 hour ago", 137},
+		{"Up 3 minutes", -1},
+		{"", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if got := exitCodeFromStatus(tt.status); got != tt.want {
+				t.Errorf("exitCodeFromStatus(%q) = %d, want %d", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitInfraContainer(t *testing.T) {
+	infraContainer := types.Container{
+		ID:     "infra-id",
+		Labels: map[string]string{PodInfraContainerLabelKey: "true"},
+	}
+	appContainer := types.Container{ID: "app-id"}
+
+	infra, userContainers := splitInfraContainer([]types.Container{infraContainer, appContainer})
+
+	if infra.ID != "infra-id" {
+		t.Errorf("infra.ID = %q, want %q", infra.ID, "infra-id")
+	}
+
+	if len(userContainers) != 1 || userContainers[0].ID != "app-id" {
+		t.Errorf("userContainers = %+v, want a single container with ID %q", userContainers, "app-id")
+	}
+}
+
+func TestSplitInfraContainer_NoInfraLabel(t *testing.T) {
+	containers := []types.Container{{ID: "a"}, {ID: "b"}}
+
+	infra, userContainers := splitInfraContainer(containers)
+
+	if infra.ID != "" {
+		t.Errorf("infra.ID = %q, want empty when no container carries the infra label", infra.ID)
+	}
+
+	if len(userContainers) != 2 {
+		t.Errorf("userContainers = %+v, want both containers treated as user containers", userContainers)
+	}
+}
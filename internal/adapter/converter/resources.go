@@ -0,0 +1,106 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+const (
+	// minCPUShares is the lowest value Docker accepts for --cpu-shares.
+	minCPUShares = 2
+
+	// defaultCPUPeriod is the standard cgroups CPU period (100ms). Together with CPUQuota it
+	// enforces a CPU limit on Docker daemons that don't honor NanoCPUs directly.
+	defaultCPUPeriod = 100000
+
+	// resourceAnnotationPrefix namespaces the labels used to surface resources Docker has no
+	// cgroups v1 equivalent for, since Docker containers have no concept of annotations.
+	resourceAnnotationPrefix = "k2d.io/resources."
+)
+
+// setResourceRequirements configures the Docker container's resource constraints based on the
+// provided core.ResourceRequirements, parsing every quantity via resource.Quantity so that
+// standard Kubernetes spellings ("100m", "1.5", "256Mi", "1Gi", "2G") are honored exactly:
+//
+//   - CPU requests map to CPUShares, a relative scheduling weight, scaled from millicores
+//     (shares = milli * 1024 / 1000) and floored at minCPUShares - CPUShares is not CPUQuota,
+//     so reusing the millicore value directly (as before) makes every container equally
+//     weighted regardless of its requested CPU.
+//   - CPU limits set NanoCPUs, plus CPUQuota/CPUPeriod for Docker daemons that predate
+//     NanoCPUs support.
+//   - Memory requests have no Docker cgroups v1 equivalent, so they are dropped from
+//     hostConfig and recorded as a label instead of silently ignored.
+//   - Memory limits use the exact byte value of the quantity, which already accounts for
+//     binary-SI suffixes like Mi/Gi.
+//   - ephemeral-storage and hugepages-* requests/limits have no Docker equivalent at all and
+//     are recorded as labels rather than dropped.
+func setResourceRequirements(containerConfig *container.Config, hostConfig *container.HostConfig, resources core.ResourceRequirements) {
+	resourceRequirements := container.Resources{}
+
+	if quantity, ok := resources.Requests[core.ResourceCPU]; ok {
+		resourceRequirements.CPUShares = cpuSharesFromQuantity(quantity)
+	}
+
+	if quantity, ok := resources.Requests[core.ResourceMemory]; ok {
+		setResourceAnnotation(containerConfig, "memory-request", quantity)
+	}
+
+	if quantity, ok := resources.Limits[core.ResourceCPU]; ok {
+		milliCPU := quantity.MilliValue()
+		resourceRequirements.NanoCPUs = milliCPU * 1_000_000
+		resourceRequirements.CPUPeriod = defaultCPUPeriod
+		resourceRequirements.CPUQuota = milliCPU * defaultCPUPeriod / 1000
+	}
+
+	if quantity, ok := resources.Limits[core.ResourceMemory]; ok {
+		resourceRequirements.Memory = quantity.Value()
+	}
+
+	for resourceName, quantity := range resources.Requests {
+		annotateUnsupportedResource(containerConfig, resourceName, "", quantity)
+	}
+
+	for resourceName, quantity := range resources.Limits {
+		annotateUnsupportedResource(containerConfig, resourceName, "-limit", quantity)
+	}
+
+	hostConfig.Resources = resourceRequirements
+}
+
+// cpuSharesFromQuantity scales a CPU request, expressed in millicores, into the relative
+// scheduling weight Docker's --cpu-shares expects.
+func cpuSharesFromQuantity(quantity resource.Quantity) int64 {
+	shares := quantity.MilliValue() * 1024 / 1000
+	if shares < minCPUShares {
+		return minCPUShares
+	}
+
+	return shares
+}
+
+// annotateUnsupportedResource records resourceName as a label on containerConfig if it has no
+// Docker cgroups equivalent (ephemeral-storage, hugepages-*); every other resource name is a
+// no-op since it is already handled by setResourceRequirements. suffix is appended to the
+// label key ("" for requests, "-limit" for limits) so a request and a limit of the same
+// resource are kept under separate labels.
+func annotateUnsupportedResource(containerConfig *container.Config, resourceName core.ResourceName, suffix string, quantity resource.Quantity) {
+	switch {
+	case resourceName == core.ResourceEphemeralStorage:
+		setResourceAnnotation(containerConfig, "ephemeral-storage"+suffix, quantity)
+	case strings.HasPrefix(string(resourceName), "hugepages-"):
+		setResourceAnnotation(containerConfig, string(resourceName)+suffix, quantity)
+	}
+}
+
+// setResourceAnnotation records a resource quantity as a label on containerConfig, using its
+// canonical Kubernetes string form (e.g. "256Mi").
+func setResourceAnnotation(containerConfig *container.Config, key string, quantity resource.Quantity) {
+	if containerConfig.Labels == nil {
+		containerConfig.Labels = map[string]string{}
+	}
+
+	containerConfig.Labels[resourceAnnotationPrefix+key] = quantity.String()
+}
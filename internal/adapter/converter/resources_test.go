@@ -0,0 +1,148 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+func TestSetResourceRequirements_CPURequests(t *testing.T) {
+	tests := []struct {
+		quantity string
+		want     int64
+	}{
+		{"100m", 102},
+		{"1.5", 1536},
+		{"2", 2048},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.quantity, func(t *testing.T) {
+			resources := core.ResourceRequirements{
+				Requests: core.ResourceList{
+					core.ResourceCPU: resource.MustParse(tt.quantity),
+				},
+			}
+
+			containerConfig := &container.Config{}
+			hostConfig := &container.HostConfig{}
+			setResourceRequirements(containerConfig, hostConfig, resources)
+
+			if hostConfig.Resources.CPUShares != tt.want {
+				t.Errorf("CPUShares = %d, want %d", hostConfig.Resources.CPUShares, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetResourceRequirements_CPUSharesFloor(t *testing.T) {
+	resources := core.ResourceRequirements{
+		Requests: core.ResourceList{
+			core.ResourceCPU: resource.MustParse("1m"),
+		},
+	}
+
+	containerConfig := &container.Config{}
+	hostConfig := &container.HostConfig{}
+	setResourceRequirements(containerConfig, hostConfig, resources)
+
+	if hostConfig.Resources.CPUShares != minCPUShares {
+		t.Errorf("CPUShares = %d, want the floor of %d", hostConfig.Resources.CPUShares, minCPUShares)
+	}
+}
+
+func TestSetResourceRequirements_MemoryLimits(t *testing.T) {
+	tests := []struct {
+		quantity string
+		want     int64
+	}{
+		{"256Mi", 256 * 1024 * 1024},
+		{"1Gi", 1024 * 1024 * 1024},
+		{"2G", 2_000_000_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.quantity, func(t *testing.T) {
+			resources := core.ResourceRequirements{
+				Limits: core.ResourceList{
+					core.ResourceMemory: resource.MustParse(tt.quantity),
+				},
+			}
+
+			containerConfig := &container.Config{}
+			hostConfig := &container.HostConfig{}
+			setResourceRequirements(containerConfig, hostConfig, resources)
+
+			if hostConfig.Resources.Memory != tt.want {
+				t.Errorf("Memory = %d, want %d", hostConfig.Resources.Memory, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetResourceRequirements_CPULimitsSetsQuotaAndPeriod(t *testing.T) {
+	resources := core.ResourceRequirements{
+		Limits: core.ResourceList{
+			core.ResourceCPU: resource.MustParse("1.5"),
+		},
+	}
+
+	containerConfig := &container.Config{}
+	hostConfig := &container.HostConfig{}
+	setResourceRequirements(containerConfig, hostConfig, resources)
+
+	if hostConfig.Resources.NanoCPUs != 1_500_000_000 {
+		t.Errorf("NanoCPUs = %d, want %d", hostConfig.Resources.NanoCPUs, 1_500_000_000)
+	}
+
+	if hostConfig.Resources.CPUPeriod != defaultCPUPeriod {
+		t.Errorf("CPUPeriod = %d, want %d", hostConfig.Resources.CPUPeriod, defaultCPUPeriod)
+	}
+
+	if want := int64(1.5 * defaultCPUPeriod); hostConfig.Resources.CPUQuota != want {
+		t.Errorf("CPUQuota = %d, want %d", hostConfig.Resources.CPUQuota, want)
+	}
+}
+
+func TestSetResourceRequirements_MemoryRequestIsAnnotatedNotReserved(t *testing.T) {
+	resources := core.ResourceRequirements{
+		Requests: core.ResourceList{
+			core.ResourceMemory: resource.MustParse("128Mi"),
+		},
+	}
+
+	containerConfig := &container.Config{}
+	hostConfig := &container.HostConfig{}
+	setResourceRequirements(containerConfig, hostConfig, resources)
+
+	if hostConfig.Resources.MemoryReservation != 0 {
+		t.Errorf("MemoryReservation = %d, want 0 - memory requests are unsupported on cgroups v1", hostConfig.Resources.MemoryReservation)
+	}
+
+	if got := containerConfig.Labels[resourceAnnotationPrefix+"memory-request"]; got != "128Mi" {
+		t.Errorf("memory-request label = %q, want %q", got, "128Mi")
+	}
+}
+
+func TestSetResourceRequirements_EphemeralStorageAndHugepagesAreAnnotated(t *testing.T) {
+	resources := core.ResourceRequirements{
+		Requests: core.ResourceList{
+			core.ResourceEphemeralStorage:      resource.MustParse("1Gi"),
+			core.ResourceName("hugepages-2Mi"): resource.MustParse("64Mi"),
+		},
+	}
+
+	containerConfig := &container.Config{}
+	hostConfig := &container.HostConfig{}
+	setResourceRequirements(containerConfig, hostConfig, resources)
+
+	if got := containerConfig.Labels[resourceAnnotationPrefix+"ephemeral-storage"]; got != "1Gi" {
+		t.Errorf("ephemeral-storage label = %q, want %q", got, "1Gi")
+	}
+
+	if got := containerConfig.Labels[resourceAnnotationPrefix+"hugepages-2Mi"]; got != "64Mi" {
+		t.Errorf("hugepages-2Mi label = %q, want %q", got, "64Mi")
+	}
+}
@@ -0,0 +1,89 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/docker/docker/api/types/registry"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+// defaultServiceAccountName is the service account a Pod is implicitly bound to when
+// spec.ServiceAccountName is left empty, matching Kubernetes' own behavior.
+const defaultServiceAccountName = "default"
+
+// dockerConfigJSON mirrors the shape of the .dockerconfigjson file Kubernetes stores in a
+// kubernetes.io/dockerconfigjson Secret, as produced by `kubectl create secret
+// docker-registry`.
+type dockerConfigJSON struct {
+	Auths map[string]struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	} `json:"auths"`
+}
+
+// ResolveImagePullAuth resolves every kubernetes.io/dockerconfigjson Secret referenced by
+// spec.ImagePullSecrets into Docker registry credentials, keyed by registry server address so
+// the caller can pick the right one as RegistryAuth when calling image.ImagePull for each
+// container's image.
+func (converter *DockerAPIConverter) ResolveImagePullAuth(namespace string, spec core.PodSpec) (map[string]registry.AuthConfig, error) {
+	auths := map[string]registry.AuthConfig{}
+
+	for _, ref := range spec.ImagePullSecrets {
+		secret, err := converter.secretStore.GetSecret(ref.Name, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get image pull secret %s: %w", ref.Name, err)
+		}
+
+		if secret.Type != core.SecretTypeDockerConfigJson {
+			return nil, fmt.Errorf("secret %s referenced by imagePullSecrets is not of type %s", ref.Name, core.SecretTypeDockerConfigJson)
+		}
+
+		var config dockerConfigJSON
+		if err := json.Unmarshal(secret.Data[core.DockerConfigJsonKey], &config); err != nil {
+			return nil, fmt.Errorf("unable to decode %s in secret %s: %w", core.DockerConfigJsonKey, ref.Name, err)
+		}
+
+		for server, entry := range config.Auths {
+			auths[server] = registry.AuthConfig{
+				Username:      entry.Username,
+				Password:      entry.Password,
+				Auth:          entry.Auth,
+				ServerAddress: server,
+			}
+		}
+	}
+
+	return auths, nil
+}
+
+// validateTLSSecret ensures a kubernetes.io/tls Secret carries both halves of the key pair
+// before it is mounted into a container.
+func validateTLSSecret(secret *core.Secret) error {
+	if secret.Type != core.SecretTypeTLS {
+		return fmt.Errorf("secret %s is not of type %s", secret.Name, core.SecretTypeTLS)
+	}
+
+	if _, ok := secret.Data[core.TLSCertKey]; !ok {
+		return fmt.Errorf("secret %s is missing %s", secret.Name, core.TLSCertKey)
+	}
+
+	if _, ok := secret.Data[core.TLSPrivateKeyKey]; !ok {
+		return fmt.Errorf("secret %s is missing %s", secret.Name, core.TLSPrivateKeyKey)
+	}
+
+	return nil
+}
+
+// serviceAccountSecretName returns the name of the kubernetes.io/service-account-token Secret
+// associated with serviceAccountName, replacing the previous hard-coded
+// K2dServiceAccountSecretName shortcut. Pods that don't specify spec.serviceAccountName fall
+// back to the default service account.
+func serviceAccountSecretName(serviceAccountName string) string {
+	if serviceAccountName == "" {
+		serviceAccountName = defaultServiceAccountName
+	}
+
+	return fmt.Sprintf("%s-token", serviceAccountName)
+}
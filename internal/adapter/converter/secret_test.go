@@ -0,0 +1,63 @@
+package converter
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+func TestValidateTLSSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  *core.Secret
+		wantErr bool
+	}{
+		{
+			name: "valid tls secret",
+			secret: &core.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "app-tls"},
+				Type:       core.SecretTypeTLS,
+				Data: map[string][]byte{
+					core.TLSCertKey:       []byte("cert"),
+					core.TLSPrivateKeyKey: []byte("key"),
+				},
+			},
+		},
+		{
+			name: "wrong type",
+			secret: &core.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "app-tls"},
+				Type:       core.SecretTypeOpaque,
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing cert",
+			secret: &core.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "app-tls"},
+				Type:       core.SecretTypeTLS,
+				Data:       map[string][]byte{core.TLSPrivateKeyKey: []byte("key")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing key",
+			secret: &core.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "app-tls"},
+				Type:       core.SecretTypeTLS,
+				Data:       map[string][]byte{core.TLSCertKey: []byte("cert")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTLSSecret(tt.secret)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateTLSSecret() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package converter
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+const (
+	// defaultSeccompProfileRoot is where Localhost seccomp profiles are resolved from when
+	// k2dServerConfiguration.SeccompProfileRoot isn't set, matching kubelet's default
+	// --root-dir/seccomp layout.
+	defaultSeccompProfileRoot = "/var/lib/kubelet/seccomp"
+
+	// legacy seccomp/AppArmor annotation keys, kept for compatibility with manifests written
+	// before SecurityContext.SeccompProfile existed.
+	podSeccompAnnotationKey            = "seccomp.security.alpha.kubernetes.io/pod"
+	containerSeccompAnnotationKeyFmt   = "container.seccomp.security.alpha.kubernetes.io/%s"
+	containerApparmorAnnotationKeyFmt  = "container.apparmor.security.beta.kubernetes.io/%s"
+)
+
+// setSecurityContext translates a Pod and container's Kubernetes SecurityContext - plus the
+// legacy seccomp/AppArmor Pod annotations - into the equivalent Docker container and host
+// configuration: user/group, capabilities, readOnlyRootFilesystem, privileged,
+// allowPrivilegeEscalation, and seccomp/AppArmor SecurityOpt entries.
+// annotations is the owning Pod's ObjectMeta.Annotations, used to resolve the legacy
+// "*.seccomp.security.alpha.kubernetes.io/*" and "container.apparmor.security.beta.kubernetes.io/*"
+// annotations for containerName. If no security context is provided, the function only
+// processes those legacy annotations. Localhost seccomp profiles are resolved relative to
+// converter.k2dServerConfiguration.SeccompProfileRoot, falling back to defaultSeccompProfileRoot
+// when it is unset.
+func (converter *DockerAPIConverter) setSecurityContext(config *container.Config, hostConfig *container.HostConfig, annotations map[string]string, containerName string, podSecurityContext *core.PodSecurityContext, containerSecurityContext *core.SecurityContext) {
+	if podSecurityContext != nil && podSecurityContext.RunAsUser != nil && podSecurityContext.RunAsGroup != nil {
+		config.User = fmt.Sprintf("%d:%d", *podSecurityContext.RunAsUser, *podSecurityContext.RunAsGroup)
+	}
+
+	seccompProfileRoot := converter.k2dServerConfiguration.SeccompProfileRoot
+	if seccompProfileRoot == "" {
+		seccompProfileRoot = defaultSeccompProfileRoot
+	}
+
+	var podSeccompProfile, containerSeccompProfile *core.SeccompProfile
+	if podSecurityContext != nil {
+		podSeccompProfile = podSecurityContext.SeccompProfile
+	}
+	if containerSecurityContext != nil {
+		containerSeccompProfile = containerSecurityContext.SeccompProfile
+	}
+
+	if seccompOpt := resolveSeccompSecurityOpt(seccompProfileRoot, annotations, containerName, podSeccompProfile, containerSeccompProfile); seccompOpt != "" {
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, seccompOpt)
+	}
+
+	if apparmorProfile := resolveApparmorProfile(annotations, containerName); apparmorProfile != "" {
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, fmt.Sprintf("apparmor=%s", apparmorProfile))
+	}
+
+	if containerSecurityContext == nil {
+		return
+	}
+
+	if containerSecurityContext.Privileged != nil {
+		hostConfig.Privileged = *containerSecurityContext.Privileged
+	}
+
+	if containerSecurityContext.ReadOnlyRootFilesystem != nil {
+		hostConfig.ReadonlyRootfs = *containerSecurityContext.ReadOnlyRootFilesystem
+	}
+
+	if containerSecurityContext.AllowPrivilegeEscalation != nil && !*containerSecurityContext.AllowPrivilegeEscalation {
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "no-new-privileges:true")
+	}
+
+	if containerSecurityContext.Capabilities != nil {
+		for _, c := range containerSecurityContext.Capabilities.Add {
+			hostConfig.CapAdd = append(hostConfig.CapAdd, string(c))
+		}
+
+		for _, c := range containerSecurityContext.Capabilities.Drop {
+			hostConfig.CapDrop = append(hostConfig.CapDrop, string(c))
+		}
+	}
+}
+
+// resolveSeccompSecurityOpt determines the Docker --security-opt seccomp= value for a
+// container, preferring the modern container-level SeccompProfile, then the pod-level one,
+// then falling back to the legacy annotations. It returns "" if no seccomp profile applies,
+// which leaves the daemon's default in place. profileRoot is where a "Localhost" profile's
+// relative path is resolved from - see defaultSeccompProfileRoot.
+func resolveSeccompSecurityOpt(profileRoot string, annotations map[string]string, containerName string, podProfile, containerProfile *core.SeccompProfile) string {
+	if containerProfile != nil {
+		return seccompOptFromProfile(profileRoot, containerProfile)
+	}
+
+	if podProfile != nil {
+		return seccompOptFromProfile(profileRoot, podProfile)
+	}
+
+	if annotations == nil {
+		return ""
+	}
+
+	if profile, ok := annotations[fmt.Sprintf(containerSeccompAnnotationKeyFmt, containerName)]; ok {
+		return seccompOptFromLegacyValue(profileRoot, profile)
+	}
+
+	if profile, ok := annotations[podSeccompAnnotationKey]; ok {
+		return seccompOptFromLegacyValue(profileRoot, profile)
+	}
+
+	return ""
+}
+
+// seccompOptFromProfile converts a core.SeccompProfile into a Docker seccomp= SecurityOpt
+// value.
+func seccompOptFromProfile(profileRoot string, profile *core.SeccompProfile) string {
+	if profile == nil {
+		return ""
+	}
+
+	switch profile.Type {
+	case core.SeccompProfileTypeUnconfined:
+		return "seccomp=unconfined"
+	case core.SeccompProfileTypeLocalhost:
+		if profile.LocalhostProfile == nil {
+			return ""
+		}
+		return fmt.Sprintf("seccomp=%s", path.Join(profileRoot, *profile.LocalhostProfile))
+	case core.SeccompProfileTypeRuntimeDefault:
+		return ""
+	default:
+		return ""
+	}
+}
+
+// seccompOptFromLegacyValue converts the value of a
+// "*.seccomp.security.alpha.kubernetes.io/*" annotation - "unconfined", "runtime/default", or
+// "localhost/<path>" - into a Docker seccomp= SecurityOpt value.
+func seccompOptFromLegacyValue(profileRoot, value string) string {
+	switch {
+	case value == "unconfined":
+		return "seccomp=unconfined"
+	case value == "runtime/default" || value == "docker/default":
+		return ""
+	case strings.HasPrefix(value, "localhost/"):
+		return fmt.Sprintf("seccomp=%s", path.Join(profileRoot, strings.TrimPrefix(value, "localhost/")))
+	default:
+		return ""
+	}
+}
+
+// resolveApparmorProfile returns the AppArmor profile name from the
+// "container.apparmor.security.beta.kubernetes.io/<name>" annotation, or "" if none is set.
+func resolveApparmorProfile(annotations map[string]string, containerName string) string {
+	if annotations == nil {
+		return ""
+	}
+
+	return annotations[fmt.Sprintf(containerApparmorAnnotationKeyFmt, containerName)]
+}
+
+// ValidateRunAsNonRoot checks a container's effective runAsNonRoot setting against the user
+// the image would otherwise run as. It is meant to be called by the pod creation path right
+// after inspecting the image, once the image's configured user is known, since Docker itself
+// has no equivalent of runAsNonRoot. imageUser is the image's Config.User field; an empty
+// value means the image defaults to running as root.
+func ValidateRunAsNonRoot(podSecurityContext *core.PodSecurityContext, containerSecurityContext *core.SecurityContext, imageUser string) error {
+	runAsNonRoot := podSecurityContext != nil && podSecurityContext.RunAsNonRoot != nil && *podSecurityContext.RunAsNonRoot
+	if containerSecurityContext != nil && containerSecurityContext.RunAsNonRoot != nil {
+		runAsNonRoot = *containerSecurityContext.RunAsNonRoot
+	}
+
+	if !runAsNonRoot {
+		return nil
+	}
+
+	if containerSecurityContext != nil && containerSecurityContext.RunAsUser != nil {
+		if *containerSecurityContext.RunAsUser == 0 {
+			return fmt.Errorf("container has runAsNonRoot set but runAsUser is 0")
+		}
+		return nil
+	}
+
+	if podSecurityContext != nil && podSecurityContext.RunAsUser != nil {
+		if *podSecurityContext.RunAsUser == 0 {
+			return fmt.Errorf("container has runAsNonRoot set but runAsUser is 0")
+		}
+		return nil
+	}
+
+	uid, _, _ := strings.Cut(imageUser, ":")
+	if uid == "" || uid == "0" || uid == "root" {
+		return fmt.Errorf("container has runAsNonRoot set but neither it nor its image declares a non-root user")
+	}
+
+	return nil
+}
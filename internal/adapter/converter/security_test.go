@@ -0,0 +1,125 @@
+package converter
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+func TestResolveSeccompSecurityOpt(t *testing.T) {
+	localhostProfile := "profiles/my-profile.json"
+
+	tests := []struct {
+		name             string
+		profileRoot      string
+		annotations      map[string]string
+		containerName    string
+		podProfile       *core.SeccompProfile
+		containerProfile *core.SeccompProfile
+		want             string
+	}{
+		{
+			name:             "container profile takes precedence over pod profile",
+			profileRoot:      "/var/lib/kubelet/seccomp",
+			containerProfile: &core.SeccompProfile{Type: core.SeccompProfileTypeUnconfined},
+			podProfile:       &core.SeccompProfile{Type: core.SeccompProfileTypeRuntimeDefault},
+			want:             "seccomp=unconfined",
+		},
+		{
+			name:        "localhost profile resolved under custom root",
+			profileRoot: "/custom/root",
+			podProfile:  &core.SeccompProfile{Type: core.SeccompProfileTypeLocalhost, LocalhostProfile: &localhostProfile},
+			want:        "seccomp=/custom/root/profiles/my-profile.json",
+		},
+		{
+			name:          "falls back to legacy container annotation",
+			profileRoot:   "/var/lib/kubelet/seccomp",
+			containerName: "app",
+			annotations:   map[string]string{"container.seccomp.security.alpha.kubernetes.io/app": "unconfined"},
+			want:          "seccomp=unconfined",
+		},
+		{
+			name: "no profile anywhere",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveSeccompSecurityOpt(tt.profileRoot, tt.annotations, tt.containerName, tt.podProfile, tt.containerProfile)
+			if got != tt.want {
+				t.Errorf("resolveSeccompSecurityOpt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeccompOptFromLegacyValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"unconfined", "unconfined", "seccomp=unconfined"},
+		{"runtime default", "runtime/default", ""},
+		{"docker default", "docker/default", ""},
+		{"localhost profile", "localhost/my-profile.json", "seccomp=/seccomp/my-profile.json"},
+		{"unrecognized value", "bogus", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := seccompOptFromLegacyValue("/seccomp", tt.value); got != tt.want {
+				t.Errorf("seccompOptFromLegacyValue(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRunAsNonRoot(t *testing.T) {
+	trueVal := true
+	zero := int64(0)
+	nonZero := int64(1000)
+
+	tests := []struct {
+		name                     string
+		podSecurityContext       *core.PodSecurityContext
+		containerSecurityContext *core.SecurityContext
+		imageUser                string
+		wantErr                  bool
+	}{
+		{
+			name: "runAsNonRoot not set",
+		},
+		{
+			name:               "runAsNonRoot set, no runAsUser, image runs as root",
+			podSecurityContext: &core.PodSecurityContext{RunAsNonRoot: &trueVal},
+			imageUser:          "",
+			wantErr:            true,
+		},
+		{
+			name:               "runAsNonRoot set, image declares non-root user",
+			podSecurityContext: &core.PodSecurityContext{RunAsNonRoot: &trueVal},
+			imageUser:          "1000",
+		},
+		{
+			name:                     "container runAsUser 0 overrides non-zero pod user",
+			podSecurityContext:       &core.PodSecurityContext{RunAsNonRoot: &trueVal, RunAsUser: &nonZero},
+			containerSecurityContext: &core.SecurityContext{RunAsUser: &zero},
+			wantErr:                  true,
+		},
+		{
+			name:               "pod runAsUser non-zero satisfies runAsNonRoot",
+			podSecurityContext: &core.PodSecurityContext{RunAsNonRoot: &trueVal, RunAsUser: &nonZero},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRunAsNonRoot(tt.podSecurityContext, tt.containerSecurityContext, tt.imageUser)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRunAsNonRoot() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
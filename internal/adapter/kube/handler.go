@@ -0,0 +1,26 @@
+package kube
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ServeHTTP makes ManifestLoader usable directly as the handler behind the k2d API server's
+// "play kube" endpoint (POST /kubernetes/play, mirroring `kubectl apply -f -`): it loads the
+// request body as a multi-document Kubernetes YAML stream via Load, and reports the first
+// error encountered as a 500. Callers that want the manifest source to also be loadable as a
+// CLI startup flag (e.g. `k2d --manifest path/to.yaml`) should open that file and pass it to
+// Load directly instead of going through this handler.
+func (l *ManifestLoader) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := l.Load(r.Body); err != nil {
+		http.Error(w, fmt.Sprintf("unable to apply manifest: %s", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
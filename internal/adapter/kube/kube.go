@@ -0,0 +1,195 @@
+// Package kube implements a "play kube" style loader that ingests a stream of Kubernetes
+// manifests and applies them against k2d's Docker-backed stores, mirroring the shape of
+// `kubectl apply -f` / Podman's PlayKube.
+package kube
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	apps "k8s.io/kubernetes/pkg/apis/apps"
+	core "k8s.io/kubernetes/pkg/apis/core"
+)
+
+// ConfigMapStore is the subset of the configmap store that the loader needs in order to
+// create ConfigMaps ahead of the workloads that reference them.
+type ConfigMapStore interface {
+	CreateConfigMap(configMap *core.ConfigMap) error
+}
+
+// SecretStore is the subset of the secret store that the loader needs in order to create
+// Secrets ahead of the workloads that reference them.
+type SecretStore interface {
+	CreateSecret(secret *core.Secret) error
+}
+
+// DeploymentAdapter is the subset of the deployment adapter that the loader needs in order
+// to create Deployments.
+type DeploymentAdapter interface {
+	CreateContainerFromDeployment(deployment *apps.Deployment) error
+}
+
+// PodAdapter is the subset of the pod adapter that the loader needs in order to create Pods.
+type PodAdapter interface {
+	CreateContainerFromPod(pod *core.Pod) error
+}
+
+// ServiceAdapter is the subset of the service adapter that the loader needs in order to
+// create Services.
+type ServiceAdapter interface {
+	CreateContainerFromService(service *core.Service) error
+}
+
+// ManifestLoader decodes a multi-document Kubernetes YAML stream and dispatches each
+// document to the store or adapter responsible for its Kind, in dependency order: ConfigMaps
+// and Secrets are always applied first so that the env and volume references made by Pods and
+// Deployments can already be resolved by the time those workloads are created.
+type ManifestLoader struct {
+	configMapStore ConfigMapStore
+	secretStore    SecretStore
+	deployment     DeploymentAdapter
+	pod            PodAdapter
+	service        ServiceAdapter
+}
+
+// NewManifestLoader creates and returns a new ManifestLoader.
+func NewManifestLoader(configMapStore ConfigMapStore, secretStore SecretStore, deployment DeploymentAdapter, pod PodAdapter, service ServiceAdapter) *ManifestLoader {
+	return &ManifestLoader{
+		configMapStore: configMapStore,
+		secretStore:    secretStore,
+		deployment:     deployment,
+		pod:            pod,
+		service:        service,
+	}
+}
+
+// Load reads a Kubernetes YAML stream, which may contain multiple `---`-separated documents
+// mixing Pod, Deployment, Service, ConfigMap and Secret objects, and applies each document in
+// dependency order. It returns the first error encountered; documents that were already
+// applied before the error are not rolled back.
+func (l *ManifestLoader) Load(manifest io.Reader) error {
+	documents, err := splitDocuments(manifest)
+	if err != nil {
+		return fmt.Errorf("unable to split manifest into documents: %w", err)
+	}
+
+	objects := make([]runtime.Object, 0, len(documents))
+	for _, document := range documents {
+		object, err := decode(document)
+		if err != nil {
+			return fmt.Errorf("unable to decode manifest document: %w", err)
+		}
+		objects = append(objects, object)
+	}
+
+	for _, phaseKinds := range [][]string{
+		{"ConfigMap", "Secret"},
+		{"Pod", "Deployment", "Service"},
+	} {
+		for _, object := range objects {
+			if err := l.applyIfKind(object, phaseKinds); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyIfKind applies object if its Kind is one of kinds, and is a no-op otherwise.
+func (l *ManifestLoader) applyIfKind(object runtime.Object, kinds []string) error {
+	kind := object.GetObjectKind().GroupVersionKind().Kind
+
+	matches := false
+	for _, k := range kinds {
+		if k == kind {
+			matches = true
+			break
+		}
+	}
+
+	if !matches {
+		return nil
+	}
+
+	switch o := object.(type) {
+	case *core.ConfigMap:
+		return l.configMapStore.CreateConfigMap(o)
+	case *core.Secret:
+		return l.secretStore.CreateSecret(o)
+	case *core.Pod:
+		return l.pod.CreateContainerFromPod(o)
+	case *apps.Deployment:
+		return l.deployment.CreateContainerFromDeployment(o)
+	case *core.Service:
+		return l.service.CreateContainerFromService(o)
+	default:
+		return fmt.Errorf("unsupported manifest kind %q", kind)
+	}
+}
+
+// splitDocuments splits a multi-document YAML stream into its individual documents.
+func splitDocuments(manifest io.Reader) ([][]byte, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(manifest))
+
+	var documents [][]byte
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+
+		documents = append(documents, document)
+	}
+
+	return documents, nil
+}
+
+// decode decodes a single YAML document into its concrete Kubernetes type, using the
+// document's kind to pick the right Go type to unmarshal into; apiVersion is not checked.
+func decode(document []byte) (runtime.Object, error) {
+	jsonDocument, err := utilyaml.ToJSON(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(jsonDocument, &typeMeta); err != nil {
+		return nil, err
+	}
+
+	var object runtime.Object
+	switch typeMeta.Kind {
+	case "ConfigMap":
+		object = &core.ConfigMap{}
+	case "Secret":
+		object = &core.Secret{}
+	case "Pod":
+		object = &core.Pod{}
+	case "Deployment":
+		object = &apps.Deployment{}
+	case "Service":
+		object = &core.Service{}
+	default:
+		return nil, fmt.Errorf("unsupported manifest kind %q", typeMeta.Kind)
+	}
+
+	if err := json.Unmarshal(jsonDocument, object); err != nil {
+		return nil, err
+	}
+
+	return object, nil
+}
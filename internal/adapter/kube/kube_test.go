@@ -0,0 +1,127 @@
+package kube
+
+import (
+	"strings"
+	"testing"
+
+	core "k8s.io/kubernetes/pkg/apis/core"
+)
+
+type fakeConfigMapStore struct {
+	created []*core.ConfigMap
+}
+
+func (f *fakeConfigMapStore) CreateConfigMap(configMap *core.ConfigMap) error {
+	f.created = append(f.created, configMap)
+	return nil
+}
+
+type fakeSecretStore struct {
+	created []*core.Secret
+}
+
+func (f *fakeSecretStore) CreateSecret(secret *core.Secret) error {
+	f.created = append(f.created, secret)
+	return nil
+}
+
+type fakePodAdapter struct {
+	created []*core.Pod
+
+	// configMapStore, when set, is inspected on every CreateContainerFromPod call to record
+	// how many ConfigMaps had already been created by then.
+	configMapStore         *fakeConfigMapStore
+	configMapsBeforeCreate []int
+}
+
+func (f *fakePodAdapter) CreateContainerFromPod(pod *core.Pod) error {
+	f.created = append(f.created, pod)
+	if f.configMapStore != nil {
+		f.configMapsBeforeCreate = append(f.configMapsBeforeCreate, len(f.configMapStore.created))
+	}
+	return nil
+}
+
+func TestSplitDocuments(t *testing.T) {
+	manifest := "kind: ConfigMap\nmetadata:\n  name: a\n---\nkind: Secret\nmetadata:\n  name: b\n"
+
+	documents, err := splitDocuments(strings.NewReader(manifest))
+	if err != nil {
+		t.Fatalf("splitDocuments returned an error: %v", err)
+	}
+
+	if len(documents) != 2 {
+		t.Fatalf("splitDocuments returned %d documents, want 2", len(documents))
+	}
+}
+
+func TestSplitDocuments_SkipsEmptyDocuments(t *testing.T) {
+	manifest := "---\nkind: ConfigMap\nmetadata:\n  name: a\n---\n---\n"
+
+	documents, err := splitDocuments(strings.NewReader(manifest))
+	if err != nil {
+		t.Fatalf("splitDocuments returned an error: %v", err)
+	}
+
+	if len(documents) != 1 {
+		t.Fatalf("splitDocuments returned %d documents, want 1", len(documents))
+	}
+}
+
+func TestDecode_UnsupportedKind(t *testing.T) {
+	_, err := decode([]byte("kind: Ingress\napiVersion: networking.k8s.io/v1\n"))
+	if err == nil {
+		t.Fatal("decode with an unsupported kind = nil error, want an error")
+	}
+}
+
+func TestDecode_ConfigMap(t *testing.T) {
+	object, err := decode([]byte("kind: ConfigMap\napiVersion: v1\nmetadata:\n  name: a\n"))
+	if err != nil {
+		t.Fatalf("decode returned an error: %v", err)
+	}
+
+	configMap, ok := object.(*core.ConfigMap)
+	if !ok {
+		t.Fatalf("decode returned %T, want *core.ConfigMap", object)
+	}
+
+	if configMap.Name != "a" {
+		t.Errorf("configMap.Name = %q, want %q", configMap.Name, "a")
+	}
+}
+
+func TestLoad_AppliesConfigMapsAndSecretsBeforeWorkloads(t *testing.T) {
+	manifest := "kind: Pod\napiVersion: v1\nmetadata:\n  name: p\n---\n" +
+		"kind: ConfigMap\napiVersion: v1\nmetadata:\n  name: cm\n"
+
+	configMapStore := &fakeConfigMapStore{}
+	secretStore := &fakeSecretStore{}
+	podAdapter := &fakePodAdapter{configMapStore: configMapStore}
+	loader := NewManifestLoader(configMapStore, secretStore, nil, podAdapter, nil)
+
+	if err := loader.Load(strings.NewReader(manifest)); err != nil {
+		t.Fatalf("Load returned an error: %v", err)
+	}
+
+	if len(configMapStore.created) != 1 {
+		t.Fatalf("configMapStore.created = %d ConfigMaps, want 1", len(configMapStore.created))
+	}
+
+	if len(podAdapter.created) != 1 {
+		t.Fatalf("podAdapter.created = %d Pods, want 1", len(podAdapter.created))
+	}
+
+	if podAdapter.configMapsBeforeCreate[0] != 1 {
+		t.Errorf("Pod was created after %d ConfigMaps, want 1 - ConfigMaps must be applied before workloads", podAdapter.configMapsBeforeCreate[0])
+	}
+}
+
+func TestLoad_UnsupportedKind(t *testing.T) {
+	loader := NewManifestLoader(&fakeConfigMapStore{}, &fakeSecretStore{}, nil, &fakePodAdapter{}, nil)
+
+	err := loader.Load(strings.NewReader("kind: Ingress\napiVersion: networking.k8s.io/v1\n"))
+	if err == nil {
+		t.Fatal("Load with an unsupported kind = nil error, want an error")
+	}
+}
@@ -0,0 +1,177 @@
+package filesystem
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+// CreateConfigMap persists configMap, writing one file per data key under the namespace
+// subdirectory of the ConfigMap directory, named "<name><ConfigMapSeparator><key>" so
+// GetConfigMapBinds and the converter package's resolveStoreBind can recover the ConfigMap a
+// bind mount belongs to.
+func (s *FileSystemStore) CreateConfigMap(configMap *core.ConfigMap) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	dir, err := s.NamespaceDir(s.configMapPath, configMap.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range configMap.Data {
+		filePath := path.Join(dir, configMapFileName(configMap.Name, key))
+		if err := os.WriteFile(filePath, []byte(value), 0644); err != nil {
+			return fmt.Errorf("unable to write configmap key %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// GetConfigMap reconstructs the ConfigMap named name in namespace from the files previously
+// written by CreateConfigMap. It returns an error if no such ConfigMap exists.
+func (s *FileSystemStore) GetConfigMap(name, namespace string) (*core.ConfigMap, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	dir, err := s.NamespaceDir(s.configMapPath, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	rawData, err := readResourceData(dir, name, ConfigMapSeparator)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read configmap %s: %w", name, err)
+	}
+
+	if len(rawData) == 0 {
+		return nil, fmt.Errorf("configmap %s not found in namespace %s", name, namespaceOrDefault(namespace))
+	}
+
+	data := make(map[string]string, len(rawData))
+	for key, value := range rawData {
+		data[key] = string(value)
+	}
+
+	return &core.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespaceOrDefault(namespace),
+			Labels:    map[string]string{NamespaceNameLabelKey: namespaceOrDefault(namespace)},
+		},
+		Data: data,
+	}, nil
+}
+
+// ListConfigMaps returns every ConfigMap stored in namespace.
+func (s *FileSystemStore) ListConfigMaps(namespace string) ([]core.ConfigMap, error) {
+	s.mutex.Lock()
+	dir, err := s.NamespaceDir(s.configMapPath, namespace)
+	s.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := resourceNames(dir, ConfigMapSeparator)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list configmaps: %w", err)
+	}
+
+	configMaps := make([]core.ConfigMap, 0, len(names))
+	for _, name := range names {
+		configMap, err := s.GetConfigMap(name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		configMaps = append(configMaps, *configMap)
+	}
+
+	return configMaps, nil
+}
+
+// GetConfigMapBinds returns the filesystem bind mappings for configMap, keyed by the file name
+// each key should appear as inside the container (the bare key) and valued by the absolute
+// host path of the file backing it.
+func (s *FileSystemStore) GetConfigMapBinds(configMap *core.ConfigMap) (map[string]string, error) {
+	s.mutex.Lock()
+	dir, err := s.NamespaceDir(s.configMapPath, configMap.Namespace)
+	s.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	binds := map[string]string{}
+	for key := range configMap.Data {
+		binds[key] = path.Join(dir, configMapFileName(configMap.Name, key))
+	}
+
+	return binds, nil
+}
+
+// configMapFileName returns the on-disk file name used to store a single key of a ConfigMap.
+func configMapFileName(name, key string) string {
+	return name + ConfigMapSeparator + key
+}
+
+// readResourceData reads every file in dir whose name is prefixed with "<name><separator>",
+// returning a map of key (the part of the file name after separator) to raw file contents.
+func readResourceData(dir, name, separator string) (map[string][]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := name + separator
+	data := map[string][]byte{}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+
+		content, err := os.ReadFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		key := strings.TrimPrefix(entry.Name(), prefix)
+		data[key] = content
+	}
+
+	return data, nil
+}
+
+// resourceNames returns the distinct resource names found in dir, derived from file names of
+// the form "<name><separator><key>".
+func resourceNames(dir, separator string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]struct{}{}
+	var names []string
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name, _, found := strings.Cut(entry.Name(), separator)
+		if !found {
+			continue
+		}
+
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
@@ -0,0 +1,136 @@
+package filesystem
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+func newTestStore(t *testing.T) *FileSystemStore {
+	t.Helper()
+
+	store, err := NewFileSystemStore(FileSystemStoreOptions{DataPath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("NewFileSystemStore returned an error: %v", err)
+	}
+
+	return store
+}
+
+func TestConfigMap_CreateAndGetRoundTrip(t *testing.T) {
+	store := newTestStore(t)
+
+	configMap := &core.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "team-a"},
+		Data:       map[string]string{"key1": "value1", "key2": "value2"},
+	}
+
+	if err := store.CreateConfigMap(configMap); err != nil {
+		t.Fatalf("CreateConfigMap returned an error: %v", err)
+	}
+
+	got, err := store.GetConfigMap("app-config", "team-a")
+	if err != nil {
+		t.Fatalf("GetConfigMap returned an error: %v", err)
+	}
+
+	if got.Data["key1"] != "value1" || got.Data["key2"] != "value2" {
+		t.Errorf("GetConfigMap data = %+v, want %+v", got.Data, configMap.Data)
+	}
+
+	if got.Namespace != "team-a" {
+		t.Errorf("GetConfigMap namespace = %q, want %q", got.Namespace, "team-a")
+	}
+}
+
+func TestConfigMap_NamespacesDoNotCollide(t *testing.T) {
+	store := newTestStore(t)
+
+	if err := store.CreateConfigMap(&core.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "team-a"},
+		Data:       map[string]string{"key": "from-team-a"},
+	}); err != nil {
+		t.Fatalf("CreateConfigMap returned an error: %v", err)
+	}
+
+	if err := store.CreateConfigMap(&core.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "team-b"},
+		Data:       map[string]string{"key": "from-team-b"},
+	}); err != nil {
+		t.Fatalf("CreateConfigMap returned an error: %v", err)
+	}
+
+	teamA, err := store.GetConfigMap("app-config", "team-a")
+	if err != nil {
+		t.Fatalf("GetConfigMap(team-a) returned an error: %v", err)
+	}
+	if teamA.Data["key"] != "from-team-a" {
+		t.Errorf("team-a data[key] = %q, want %q", teamA.Data["key"], "from-team-a")
+	}
+
+	teamB, err := store.GetConfigMap("app-config", "team-b")
+	if err != nil {
+		t.Fatalf("GetConfigMap(team-b) returned an error: %v", err)
+	}
+	if teamB.Data["key"] != "from-team-b" {
+		t.Errorf("team-b data[key] = %q, want %q", teamB.Data["key"], "from-team-b")
+	}
+}
+
+func TestConfigMap_GetMissingReturnsError(t *testing.T) {
+	store := newTestStore(t)
+
+	if _, err := store.GetConfigMap("missing", "default"); err == nil {
+		t.Error("GetConfigMap for a missing configmap = nil error, want an error")
+	}
+}
+
+func TestConfigMap_GetConfigMapBinds(t *testing.T) {
+	store := newTestStore(t)
+
+	configMap := &core.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "team-a"},
+		Data:       map[string]string{"key1": "value1", "key2": "value2"},
+	}
+
+	if err := store.CreateConfigMap(configMap); err != nil {
+		t.Fatalf("CreateConfigMap returned an error: %v", err)
+	}
+
+	binds, err := store.GetConfigMapBinds(configMap)
+	if err != nil {
+		t.Fatalf("GetConfigMapBinds returned an error: %v", err)
+	}
+
+	if len(binds) != 2 {
+		t.Fatalf("GetConfigMapBinds returned %d binds, want 2", len(binds))
+	}
+
+	if binds["key1"] == "" || binds["key2"] == "" {
+		t.Errorf("GetConfigMapBinds = %+v, want non-empty host paths for both keys", binds)
+	}
+}
+
+func TestConfigMap_ListConfigMaps(t *testing.T) {
+	store := newTestStore(t)
+
+	for _, name := range []string{"a", "b"} {
+		if err := store.CreateConfigMap(&core.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+			Data:       map[string]string{"key": "value"},
+		}); err != nil {
+			t.Fatalf("CreateConfigMap(%s) returned an error: %v", name, err)
+		}
+	}
+
+	configMaps, err := store.ListConfigMaps("team-a")
+	if err != nil {
+		t.Fatalf("ListConfigMaps returned an error: %v", err)
+	}
+
+	if len(configMaps) != 2 {
+		t.Errorf("ListConfigMaps returned %d configmaps, want 2", len(configMaps))
+	}
+}
+
@@ -0,0 +1,132 @@
+package filesystem
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+// CreateSecret persists secret, writing one file per data key under the namespace
+// subdirectory of the Secret directory, named "<name><SecretSeparator><key>" so
+// GetSecretBinds and the converter package's resolveStoreBind can recover the Secret a bind
+// mount belongs to. secret.Type is persisted separately in a "<name>.type" metadata file, since
+// ResolveImagePullAuth and validateTLSSecret both key their behavior off of it.
+func (s *FileSystemStore) CreateSecret(secret *core.Secret) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	dir, err := s.NamespaceDir(s.secretPath, secret.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range secret.Data {
+		filePath := path.Join(dir, secretFileName(secret.Name, key))
+		if err := os.WriteFile(filePath, value, 0600); err != nil {
+			return fmt.Errorf("unable to write secret key %s: %w", key, err)
+		}
+	}
+
+	typeFilePath := path.Join(dir, secretTypeFileName(secret.Name))
+	if err := os.WriteFile(typeFilePath, []byte(secret.Type), 0600); err != nil {
+		return fmt.Errorf("unable to write secret type: %w", err)
+	}
+
+	return nil
+}
+
+// GetSecret reconstructs the Secret named name in namespace from the files previously written
+// by CreateSecret. It returns an error if no such Secret exists.
+func (s *FileSystemStore) GetSecret(name, namespace string) (*core.Secret, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	dir, err := s.NamespaceDir(s.secretPath, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := readResourceData(dir, name, SecretSeparator)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read secret %s: %w", name, err)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("secret %s not found in namespace %s", name, namespaceOrDefault(namespace))
+	}
+
+	secretType, err := os.ReadFile(path.Join(dir, secretTypeFileName(name)))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("unable to read type for secret %s: %w", name, err)
+	}
+
+	return &core.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespaceOrDefault(namespace),
+			Labels:    map[string]string{NamespaceNameLabelKey: namespaceOrDefault(namespace)},
+		},
+		Type: core.SecretType(secretType),
+		Data: data,
+	}, nil
+}
+
+// ListSecrets returns every Secret stored in namespace.
+func (s *FileSystemStore) ListSecrets(namespace string) ([]core.Secret, error) {
+	s.mutex.Lock()
+	dir, err := s.NamespaceDir(s.secretPath, namespace)
+	s.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := resourceNames(dir, SecretSeparator)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list secrets: %w", err)
+	}
+
+	secrets := make([]core.Secret, 0, len(names))
+	for _, name := range names {
+		secret, err := s.GetSecret(name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, *secret)
+	}
+
+	return secrets, nil
+}
+
+// GetSecretBinds returns the filesystem bind mappings for secret, keyed by the file name each
+// key should appear as inside the container (the bare key) and valued by the absolute host
+// path of the file backing it.
+func (s *FileSystemStore) GetSecretBinds(secret *core.Secret) (map[string]string, error) {
+	s.mutex.Lock()
+	dir, err := s.NamespaceDir(s.secretPath, secret.Namespace)
+	s.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	binds := map[string]string{}
+	for key := range secret.Data {
+		binds[key] = path.Join(dir, secretFileName(secret.Name, key))
+	}
+
+	return binds, nil
+}
+
+// secretFileName returns the on-disk file name used to store a single key of a Secret.
+func secretFileName(name, key string) string {
+	return name + SecretSeparator + key
+}
+
+// secretTypeFileName returns the on-disk file name used to store a Secret's Type. It
+// deliberately doesn't contain SecretSeparator, so readResourceData and resourceNames - which
+// key off of it - never mistake this metadata file for a data key.
+func secretTypeFileName(name string) string {
+	return name + ".type"
+}
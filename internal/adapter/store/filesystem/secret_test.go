@@ -0,0 +1,87 @@
+package filesystem
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+func TestSecret_CreateAndGetRoundTrip(t *testing.T) {
+	store := newTestStore(t)
+
+	secret := &core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-token", Namespace: "team-a"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	}
+
+	if err := store.CreateSecret(secret); err != nil {
+		t.Fatalf("CreateSecret returned an error: %v", err)
+	}
+
+	got, err := store.GetSecret("app-token", "team-a")
+	if err != nil {
+		t.Fatalf("GetSecret returned an error: %v", err)
+	}
+
+	if string(got.Data["token"]) != "s3cr3t" {
+		t.Errorf("GetSecret data[token] = %q, want %q", got.Data["token"], "s3cr3t")
+	}
+}
+
+func TestSecret_NamespacesDoNotCollide(t *testing.T) {
+	store := newTestStore(t)
+
+	if err := store.CreateSecret(&core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-token", Namespace: "team-a"},
+		Data:       map[string][]byte{"token": []byte("team-a-secret")},
+	}); err != nil {
+		t.Fatalf("CreateSecret returned an error: %v", err)
+	}
+
+	if err := store.CreateSecret(&core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-token", Namespace: "team-b"},
+		Data:       map[string][]byte{"token": []byte("team-b-secret")},
+	}); err != nil {
+		t.Fatalf("CreateSecret returned an error: %v", err)
+	}
+
+	teamA, err := store.GetSecret("app-token", "team-a")
+	if err != nil {
+		t.Fatalf("GetSecret(team-a) returned an error: %v", err)
+	}
+	if string(teamA.Data["token"]) != "team-a-secret" {
+		t.Errorf("team-a token = %q, want %q", teamA.Data["token"], "team-a-secret")
+	}
+}
+
+func TestSecret_GetMissingReturnsError(t *testing.T) {
+	store := newTestStore(t)
+
+	if _, err := store.GetSecret("missing", "default"); err == nil {
+		t.Error("GetSecret for a missing secret = nil error, want an error")
+	}
+}
+
+func TestSecret_TypeSurvivesRoundTrip(t *testing.T) {
+	store := newTestStore(t)
+
+	secret := &core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry-creds", Namespace: "team-a"},
+		Type:       core.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{core.DockerConfigJsonKey: []byte("{}")},
+	}
+
+	if err := store.CreateSecret(secret); err != nil {
+		t.Fatalf("CreateSecret returned an error: %v", err)
+	}
+
+	got, err := store.GetSecret("registry-creds", "team-a")
+	if err != nil {
+		t.Fatalf("GetSecret returned an error: %v", err)
+	}
+
+	if got.Type != core.SecretTypeDockerConfigJson {
+		t.Errorf("GetSecret Type = %q, want %q", got.Type, core.SecretTypeDockerConfigJson)
+	}
+}
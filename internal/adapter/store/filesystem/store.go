@@ -2,6 +2,7 @@ package filesystem
 
 import (
 	"fmt"
+	"os"
 	"path"
 	"sync"
 
@@ -18,6 +19,11 @@ const (
 	FilePathAnnotationKey = "store.k2d.io/filesystem/path"
 	SecretFolder          = "secrets"
 	SecretSeparator       = "-k2dsec-"
+	NamespaceFolder       = "namespaces"
+
+	// DefaultNamespace is the namespace flat (pre-namespace-aware) data is migrated into,
+	// and the namespace assumed when none is specified.
+	DefaultNamespace = "default"
 
 	// NamespaceNameLabelKey is the key used to store the namespace of a Configmap or Secret resource
 	// in the associated metadata file
@@ -29,11 +35,15 @@ const (
 // It can be used to store ConfigMaps and Secrets.
 // It holds paths to the configMap and secret directories,
 // and a mutex to handle concurrent access.
+// Both directories are namespace-aware: each resource lives under a subdirectory named after
+// its namespace, e.g. <configMapPath>/<namespace>/<name>-k2dcm-<key>. Every namespace a
+// ConfigMap or Secret is created in is recorded in namespaceStore.
 type (
 	FileSystemStore struct {
-		configMapPath string
-		secretPath    string
-		mutex         sync.Mutex
+		configMapPath  string
+		secretPath     string
+		namespaceStore *NamespaceStore
+		mutex          sync.Mutex
 	}
 )
 
@@ -45,6 +55,8 @@ type FileSystemStoreOptions struct {
 // NewFileSystemStore creates and returns a new FileSystemStore.
 // It receives a data path where the directories for configMaps and secrets are created.
 // If the directories cannot be created, an error is returned.
+// Existing flat (pre-namespace-aware) data found directly under configmaps/ or secrets/ is
+// migrated into the DefaultNamespace subdirectory.
 func NewFileSystemStore(opts FileSystemStoreOptions) (*FileSystemStore, error) {
 	folders := []string{ConfigMapFolder, SecretFolder}
 
@@ -55,9 +67,165 @@ func NewFileSystemStore(opts FileSystemStoreOptions) (*FileSystemStore, error) {
 		}
 	}
 
-	return &FileSystemStore{
-		configMapPath: path.Join(opts.DataPath, ConfigMapFolder),
-		secretPath:    path.Join(opts.DataPath, SecretFolder),
+	namespaceStore, err := NewNamespaceStore(opts.DataPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create namespace store: %w", err)
+	}
+
+	store := &FileSystemStore{
+		configMapPath:  path.Join(opts.DataPath, ConfigMapFolder),
+		secretPath:     path.Join(opts.DataPath, SecretFolder),
+		namespaceStore: namespaceStore,
+		mutex:          sync.Mutex{},
+	}
+
+	if err := store.migrateFlatLayout(store.configMapPath); err != nil {
+		return nil, fmt.Errorf("unable to migrate configmap data to namespace layout: %w", err)
+	}
+
+	if err := store.migrateFlatLayout(store.secretPath); err != nil {
+		return nil, fmt.Errorf("unable to migrate secret data to namespace layout: %w", err)
+	}
+
+	return store, nil
+}
+
+// namespaceOrDefault returns namespace, or DefaultNamespace if it is empty.
+func namespaceOrDefault(namespace string) string {
+	if namespace == "" {
+		return DefaultNamespace
+	}
+
+	return namespace
+}
+
+// NamespaceDir returns (creating it if necessary) the namespace-scoped subdirectory of
+// basePath, which must be either the store's configMap or secret directory. The namespace is
+// also recorded in namespaceStore, so every namespace a ConfigMap or Secret is created in is
+// tracked even if it was never explicitly created as a Namespace resource.
+func (s *FileSystemStore) NamespaceDir(basePath, namespace string) (string, error) {
+	namespace = namespaceOrDefault(namespace)
+
+	dir := path.Join(basePath, namespace)
+	if err := filesystem.CreateDir(dir); err != nil {
+		return "", fmt.Errorf("unable to create namespace directory %s: %w", dir, err)
+	}
+
+	if err := s.namespaceStore.CreateNamespace(namespace); err != nil {
+		return "", fmt.Errorf("unable to record namespace %s: %w", namespace, err)
+	}
+
+	return dir, nil
+}
+
+// migrateFlatLayout moves any regular file found directly under basePath - the layout used
+// before namespaces were introduced - into the DefaultNamespace subdirectory, so upgrades
+// from older k2d data directories keep working.
+func (s *FileSystemStore) migrateFlatLayout(basePath string) error {
+	entries, err := os.ReadDir(basePath)
+	if err != nil {
+		return err
+	}
+
+	var flatFiles []os.DirEntry
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			flatFiles = append(flatFiles, entry)
+		}
+	}
+
+	if len(flatFiles) == 0 {
+		return nil
+	}
+
+	defaultDir, err := s.NamespaceDir(basePath, DefaultNamespace)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range flatFiles {
+		oldPath := path.Join(basePath, entry.Name())
+		newPath := path.Join(defaultDir, entry.Name())
+
+		if err := os.Rename(oldPath, newPath); err != nil {
+			return fmt.Errorf("unable to migrate %s to %s: %w", oldPath, newPath, err)
+		}
+	}
+
+	return nil
+}
+
+// NamespaceStore tracks the set of namespaces that have been created in this k2d instance,
+// backed by one empty marker file per namespace under a dedicated directory.
+type NamespaceStore struct {
+	namespacePath string
+	mutex         sync.Mutex
+}
+
+// NewNamespaceStore creates and returns a new NamespaceStore, ensuring the DefaultNamespace
+// is always present.
+func NewNamespaceStore(dataPath string) (*NamespaceStore, error) {
+	namespacePath := path.Join(dataPath, NamespaceFolder)
+
+	if err := filesystem.CreateDir(namespacePath); err != nil {
+		return nil, fmt.Errorf("unable to create directory %s: %w", NamespaceFolder, err)
+	}
+
+	store := &NamespaceStore{
+		namespacePath: namespacePath,
 		mutex:         sync.Mutex{},
-	}, nil
+	}
+
+	if err := store.CreateNamespace(DefaultNamespace); err != nil {
+		return nil, fmt.Errorf("unable to create default namespace: %w", err)
+	}
+
+	return store, nil
+}
+
+// CreateNamespace records that namespace exists. It is idempotent.
+func (s *NamespaceStore) CreateNamespace(namespace string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	file, err := os.Create(path.Join(s.namespacePath, namespace))
+	if err != nil {
+		return fmt.Errorf("unable to create namespace marker for %s: %w", namespace, err)
+	}
+
+	return file.Close()
+}
+
+// NamespaceExists reports whether namespace has previously been created.
+func (s *NamespaceStore) NamespaceExists(namespace string) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, err := os.Stat(path.Join(s.namespacePath, namespace))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("unable to check namespace %s: %w", namespace, err)
+	}
+
+	return true, nil
+}
+
+// ListNamespaces returns the names of every namespace that has been created.
+func (s *NamespaceStore) ListNamespaces() ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entries, err := os.ReadDir(s.namespacePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list namespaces: %w", err)
+	}
+
+	namespaces := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		namespaces = append(namespaces, entry.Name())
+	}
+
+	return namespaces, nil
 }
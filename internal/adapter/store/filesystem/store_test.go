@@ -0,0 +1,56 @@
+package filesystem
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+func TestNewFileSystemStore_RecordsDefaultNamespace(t *testing.T) {
+	store := newTestStore(t)
+
+	exists, err := store.namespaceStore.NamespaceExists(DefaultNamespace)
+	if err != nil {
+		t.Fatalf("NamespaceExists returned an error: %v", err)
+	}
+
+	if !exists {
+		t.Error("NamespaceExists(default) = false, want true after NewFileSystemStore")
+	}
+}
+
+func TestNamespaceDir_RecordsNamespace(t *testing.T) {
+	store := newTestStore(t)
+
+	if err := store.CreateConfigMap(&core.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "team-a"},
+		Data:       map[string]string{"key": "value"},
+	}); err != nil {
+		t.Fatalf("CreateConfigMap returned an error: %v", err)
+	}
+
+	exists, err := store.namespaceStore.NamespaceExists("team-a")
+	if err != nil {
+		t.Fatalf("NamespaceExists returned an error: %v", err)
+	}
+
+	if !exists {
+		t.Error("NamespaceExists(team-a) = false, want true after creating a ConfigMap in it")
+	}
+
+	namespaces, err := store.namespaceStore.ListNamespaces()
+	if err != nil {
+		t.Fatalf("ListNamespaces returned an error: %v", err)
+	}
+
+	var found bool
+	for _, ns := range namespaces {
+		if ns == "team-a" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("ListNamespaces() = %v, want it to contain %q", namespaces, "team-a")
+	}
+}